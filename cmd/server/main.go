@@ -12,13 +12,21 @@ import (
 
 	_ "matching-api/docs"
 	"matching-api/internal/database"
+	"matching-api/internal/handlers/admin"
 	"matching-api/internal/handlers/auth"
+	"matching-api/internal/handlers/boost"
 	"matching-api/internal/handlers/chat"
 	"matching-api/internal/handlers/image"
 	"matching-api/internal/handlers/match"
 	"matching-api/internal/handlers/notification"
 	"matching-api/internal/handlers/user"
 	customMiddleware "matching-api/internal/middleware"
+	"matching-api/internal/models"
+	internalServices "matching-api/internal/services"
+	jwtauth "matching-api/pkg/auth"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/gifs"
+	"matching-api/pkg/ratelimit"
 	"matching-api/pkg/services"
 
 	"github.com/go-chi/chi/v5"
@@ -61,6 +69,12 @@ func main() {
 		if err := database.RunMigrations(); err != nil {
 			log.Printf("Migration failed: %v", err)
 		}
+		// Index locations stored before geohash indexing was added
+		if updated, err := database.BackfillGeohashes(); err != nil {
+			log.Printf("Geohash backfill failed: %v", err)
+		} else if updated > 0 {
+			log.Printf("Backfilled geohashes for %d users", updated)
+		}
 	}
 
 	// Create router
@@ -99,6 +113,15 @@ func main() {
 			}
 		}()
 		log.Printf("Redis connected successfully")
+
+		// Drop cache entries left behind by other key versions
+		go func() {
+			if purged, err := redisService.PurgeStaleCacheKeys(); err != nil {
+				log.Printf("Failed to purge stale cache keys: %v", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d stale cache keys", purged)
+			}
+		}()
 	}
 
 	// Add Chi session middleware with Redis support
@@ -108,6 +131,9 @@ func main() {
 		r.Use(customMiddleware.NewSessionMiddleware(nil)) // Fallback to memory
 	}
 
+	// Response cache selected by CACHE_BACKEND (Redis, in-memory or both)
+	cacheStore := cache.New(redisService)
+
 	// Initialize S3 service
 	var s3Service *services.S3Service
 	if bucketName := os.Getenv("AWS_S3_BUCKET"); bucketName != "" {
@@ -123,23 +149,64 @@ func main() {
 		}
 	}
 
+	// Access token revocation (Redis-backed, in-memory fallback)
+	revocationStore := jwtauth.NewRevocationStore(redisService)
+
+	// Background jobs run until shutdown
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+
+	// Account deletion purges accounts once their grace period has passed
+	accountDeletionService := internalServices.NewAccountDeletionService(s3Service, redisService, cacheStore, revocationStore)
+	go accountDeletionService.Start(jobsCtx, time.Hour)
+
+	// Data exports are built by a background worker and announced by notification
+	dataExportService := internalServices.NewDataExportService(s3Service, internalServices.NewNotificationService())
+	go dataExportService.Start(jobsCtx, 10*time.Minute)
+
+	// Daily like and super like allowances, by subscription tier
+	quotaService := internalServices.NewQuotaService(redisService, internalServices.NewTierEntitlementProvider())
+
+	// Matches nobody writes in expire after MATCH_EXPIRY_HOURS, with a reminder a day ahead
+	matchExpiryService := internalServices.NewMatchExpiryService(cacheStore, internalServices.NewNotificationService())
+	go matchExpiryService.Start(jobsCtx, 15*time.Minute)
+
+	// Boosts rank users higher in discovery for a fixed window
+	boostService := internalServices.NewBoostService()
+
+	// GIF search through a Giphy-compatible provider (GIF_API_KEY, GIF_API_BASE_URL)
+	gifService, err := gifs.NewServiceFromEnv(cacheStore)
+	if err != nil {
+		log.Fatalf("Invalid GIF configuration: %v", err)
+	}
+
+	// Named rate limit policies from configs/app.yaml (or RATE_LIMIT_CONFIG)
+	rateLimitConfig, err := ratelimit.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid rate limit configuration: %v", err)
+	}
+	rateLimits := customMiddleware.NewRateLimits(redisService, cacheStore, rateLimitConfig, quotaService.Tier)
+
 	// Initialize handlers with new organized structure
-	authHandler := auth.NewHandler(redisService)
-	userHandler := user.NewHandler(s3Service, redisService)
-	matchHandler := match.NewHandler(redisService)
-	chatHandler := chat.NewHandler(redisService)
-	notificationHandler := notification.NewHandler(redisService)
-	imageHandler := image.NewHandler(s3Service, redisService)
+	authHandler := auth.NewHandler(redisService, cacheStore, revocationStore)
+	userHandler := user.NewHandler(s3Service, redisService, cacheStore, accountDeletionService, dataExportService)
+	matchHandler := match.NewHandler(redisService, cacheStore, quotaService, boostService, matchExpiryService)
+	chatHandler := chat.NewHandler(s3Service, redisService, cacheStore, rateLimits, gifService)
+	notificationHandler := notification.NewHandler(redisService, cacheStore)
+	imageHandler := image.NewHandler(s3Service, redisService, cacheStore)
+	adminHandler := admin.NewHandler(redisService, cacheStore, revocationStore)
+	boostHandler := boost.NewHandler(redisService, cacheStore, boostService)
 
 	// Swagger documentation
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
 	// Routes
 	r.Route("/api/v1", func(r chi.Router) {
-		// Add Redis-based rate limiting if available
-		if redisService != nil {
-			r.Use(customMiddleware.APIRateLimit(redisService))
-		}
+		// Identify the user where possible so only anonymous requests are
+		// limited per IP, and authenticated ones by the user's tier
+		r.Use(customMiddleware.WebSocketToken)
+		r.Use(customMiddleware.OptionalAuth(revocationStore))
+		r.Use(rateLimits.LimitAnonymous("api"))
 
 		// Health check
 		r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -170,49 +237,79 @@ func main() {
 
 		// Auth routes (public)
 		r.Route("/auth", func(r chi.Router) {
-			// Add stricter rate limiting for auth endpoints
-			if redisService != nil {
-				r.Use(customMiddleware.AuthRateLimit(redisService))
-			}
+			// Add stricter rate limiting for auth endpoints (falls back to in-memory without Redis)
+			r.Use(rateLimits.Limit("auth"))
 
-			r.Post("/register", authHandler.Register)
+			r.With(rateLimits.Limit("register")).Post("/register", authHandler.Register)
 			r.Post("/login", authHandler.Login)
 			r.Post("/refresh", authHandler.RefreshToken)
 			r.Post("/logout", authHandler.Logout)
+
+			// Session management (requires authentication)
+			r.Group(func(r chi.Router) {
+				r.Use(customMiddleware.AuthMiddleware(revocationStore))
+				r.Get("/sessions", authHandler.GetSessions)
+				r.Delete("/sessions/{sessionID}", authHandler.RevokeSession)
+				r.Post("/logout-all", authHandler.LogoutAll)
+			})
 		})
 
 		// Protected routes
 		r.Route("/", func(r chi.Router) {
-			r.Use(customMiddleware.AuthMiddleware)
+			r.Use(customMiddleware.AuthMiddleware(revocationStore))
 
 			// Add user-based rate limiting for authenticated users
-			if redisService != nil {
-				r.Use(customMiddleware.StrictRateLimit(redisService))
-			}
+			r.Use(rateLimits.Limit("user"))
 
 			// User routes
 			r.Route("/users", func(r chi.Router) {
 				r.Get("/profile", userHandler.GetProfile)
 				r.Put("/profile", userHandler.UpdateProfile)
-				r.Post("/photos", userHandler.UploadPhoto)
+				r.With(rateLimits.Limit("image_upload")).Post("/photos", userHandler.UploadPhoto)
 				r.Delete("/photos/{photoID}", userHandler.DeletePhoto)
 				r.Put("/preferences", userHandler.UpdatePreferences)
 				r.Get("/preferences", userHandler.GetPreferences)
+				r.Delete("/me", userHandler.DeleteAccount)
+				r.Get("/me/deletion", userHandler.GetAccountDeletion)
+				r.Delete("/me/deletion", userHandler.CancelAccountDeletion)
+				r.Post("/me/export", userHandler.RequestDataExport)
+				r.Get("/me/export/{exportID}", userHandler.GetDataExport)
 			})
 
 			// Match routes
 			r.Route("/matches", func(r chi.Router) {
-				r.Post("/swipe", matchHandler.Swipe)
+				r.With(rateLimits.Limit("swipe")).Post("/swipe", matchHandler.Swipe)
+				r.Post("/rewind", matchHandler.Rewind)
 				r.Get("/", matchHandler.GetMatches)
 				r.Get("/potential", matchHandler.GetPotentialMatches)
+				r.Get("/quota", matchHandler.GetQuota)
+				r.Get("/likes-received", matchHandler.GetLikesReceived)
+				r.With(rateLimits.Limit("swipe")).Post("/likes-received/{likeID}/like-back", matchHandler.LikeBack)
+				r.Post("/{matchID}/extend", matchHandler.ExtendMatch)
 				r.Delete("/{matchID}", matchHandler.UnMatch)
 			})
 
+			// Boost routes
+			r.Route("/boosts", func(r chi.Router) {
+				r.Post("/", boostHandler.ActivateBoost)
+				r.Get("/active", boostHandler.GetActiveBoost)
+			})
+
 			// Chat routes
 			r.Route("/chats", func(r chi.Router) {
 				r.Get("/", chatHandler.GetChats)
+				r.With(rateLimits.Limit("gif_search")).Get("/gifs/search", chatHandler.SearchGIFs)
+				r.With(rateLimits.Limit("gif_search")).Get("/gifs/trending", chatHandler.TrendingGIFs)
 				r.Get("/{chatID}/messages", chatHandler.GetMessages)
-				r.Post("/{chatID}/messages", chatHandler.SendMessage)
+				r.With(rateLimits.Limit("message_send")).Post("/{chatID}/messages", chatHandler.SendMessage)
+				r.Post("/{chatID}/read", chatHandler.MarkRead)
+				r.With(rateLimits.Limit("image_upload")).Post("/{chatID}/attachments", chatHandler.UploadAttachment)
+				r.Get("/{chatID}/attachments/{attachmentID}", chatHandler.GetAttachment)
+				r.With(rateLimits.Limit("message_send")).Put("/{chatID}/messages/{messageID}", chatHandler.EditMessage)
+				r.Delete("/{chatID}/messages/{messageID}", chatHandler.DeleteMessage)
+				r.Get("/{chatID}/messages/{messageID}/edits", chatHandler.GetMessageEdits)
+				r.Put("/{chatID}/messages/{messageID}/reaction", chatHandler.SetReaction)
+				r.Delete("/{chatID}/messages/{messageID}/reaction", chatHandler.RemoveReaction)
 			})
 
 			// Notification routes
@@ -231,16 +328,46 @@ func main() {
 			// Image routes
 			r.Route("/images", func(r chi.Router) {
 				r.Get("/", imageHandler.ListUserImages)
-				r.Post("/upload", imageHandler.UploadImage)
-				r.Post("/upload-base64", imageHandler.UploadImageBase64)
-				r.Post("/presigned-upload", imageHandler.GeneratePresignedUploadURL)
+				r.Group(func(r chi.Router) {
+					r.Use(rateLimits.Limit("image_upload"))
+					r.Post("/upload", imageHandler.UploadImage)
+					r.Post("/upload-base64", imageHandler.UploadImageBase64)
+					r.Post("/presigned-upload", imageHandler.GeneratePresignedUploadURL)
+				})
 				r.Get("/download/{imageKey}", imageHandler.DownloadImage)
 				r.Delete("/{imageKey}", imageHandler.DeleteImage)
 			})
+
+			// Admin routes (moderators and admins)
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(customMiddleware.RequireRole(models.RoleModerator))
+
+				r.Get("/users", adminHandler.SearchUsers)
+				r.Get("/users/{userID}", adminHandler.GetUser)
+				r.Post("/users/{userID}/suspend", adminHandler.SuspendUser)
+				r.Post("/users/{userID}/unsuspend", adminHandler.UnsuspendUser)
+				r.Post("/images/{imageID}/moderate", adminHandler.ModerateImage)
+				r.Get("/moderation/actions", adminHandler.GetModerationActions)
+
+				// Admin only
+				r.Group(func(r chi.Router) {
+					r.Use(customMiddleware.RequireRole(models.RoleAdmin))
+					r.Put("/users/{userID}/role", adminHandler.UpdateUserRole)
+					r.Get("/metrics/dashboard", adminHandler.GetDashboardMetrics)
+					r.Get("/metrics/funnel", adminHandler.GetFunnelAnalysis)
+					r.Get("/metrics/events", adminHandler.GetEventSummary)
+					r.Get("/metrics/users/{userID}", adminHandler.GetUserMetrics)
+				})
+			})
 		})
 
-		// WebSocket endpoint
-		r.Get("/ws", chatHandler.HandleWebSocket)
+		// WebSocket endpoint. Browsers can't set headers on WebSocket
+		// requests, so WebSocketToken above also takes the access token
+		// from a subprotocol.
+		r.Group(func(r chi.Router) {
+			r.Use(customMiddleware.AuthMiddleware(revocationStore))
+			r.Get("/ws", chatHandler.HandleWebSocket)
+		})
 	})
 
 	// Server configuration
@@ -266,6 +393,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
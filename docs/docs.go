@@ -24,9 +24,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
+        "/admin/images/{imageID}/moderate": {
             "post": {
-                "description": "Authenticate user with email and password",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve an image or remove it from the owner's profile (moderator or admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -34,37 +39,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "User login",
+                "summary": "Moderate image",
                 "parameters": [
                     {
-                        "description": "User login credentials",
+                        "type": "string",
+                        "description": "Image ID",
+                        "name": "imageID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation decision",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.ModerateImageRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Image moderated successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.AuthResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "400": {
@@ -74,7 +74,19 @@ const docTemplate = `{
                         }
                     },
                     "401": {
-                        "description": "Invalid credentials",
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -88,9 +100,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/logout": {
-            "post": {
-                "description": "Logout user by invalidating refresh token and clearing session",
+        "/admin/metrics/dashboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get overview, engagement, revenue, geographic, demographic and trending metrics (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -98,29 +115,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
-                ],
-                "summary": "User logout",
-                "parameters": [
-                    {
-                        "description": "Refresh token to invalidate",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RefreshTokenRequest"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "Get dashboard metrics",
                 "responses": {
                     "200": {
-                        "description": "Logout successful",
+                        "description": "Dashboard metrics retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DashboardMetrics"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - validation failed",
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -134,9 +158,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "post": {
-                "description": "Generate new access token using refresh token",
+        "/admin/metrics/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get event counts and unique users per event type (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -144,23 +173,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Get event summary",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RefreshTokenRequest"
-                        }
+                        "type": "integer",
+                        "default": 7,
+                        "description": "Number of days to summarize (max 90)",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token refreshed successfully",
+                        "description": "Event summary retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -170,21 +197,32 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AuthResponse"
+                                            "type": "object",
+                                            "properties": {
+                                                "days": {
+                                                    "type": "integer"
+                                                },
+                                                "events": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.EventSummary"
+                                                    }
+                                                }
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - validation failed",
+                    "401": {
+                        "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Invalid refresh token",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -198,9 +236,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user account with email and password",
+        "/admin/metrics/funnel": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the user conversion funnel from install to active user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -208,23 +251,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
-                ],
-                "summary": "Register a new user",
-                "parameters": [
-                    {
-                        "description": "User registration data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RegisterRequest"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "Get funnel analysis",
                 "responses": {
-                    "201": {
-                        "description": "User registered successfully",
+                    "200": {
+                        "description": "Funnel analysis retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -234,15 +266,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.AuthResponse"
+                                            "$ref": "#/definitions/models.FunnelAnalysis"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - validation failed",
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -256,14 +294,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chats": {
+        "/admin/metrics/users/{userID}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve user's chat conversations with pagination",
+                "description": "Get swipe, match and messaging metrics for a user (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -271,28 +309,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "Admin"
                 ],
-                "summary": "Get user chats",
+                "summary": "Get user metrics",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of chats per page (max 50)",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Chats retrieved successfully",
+                        "description": "User metrics retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -302,38 +333,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "chats": {
-                                                    "type": "array",
-                                                    "items": {
-                                                        "$ref": "#/definitions/models.Chat"
-                                                    }
-                                                },
-                                                "limit": {
-                                                    "type": "integer"
-                                                },
-                                                "page": {
-                                                    "type": "integer"
-                                                },
-                                                "total": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/models.UserMetrics"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - invalid pagination",
+                    "401": {
+                        "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid token",
+                    "403": {
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -347,14 +361,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/chats/{chatID}/messages": {
+        "/admin/moderation/actions": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve messages for a specific chat conversation with pagination",
+                "description": "List moderation actions, newest first (moderator or admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -362,35 +376,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "Admin"
                 ],
-                "summary": "Get chat messages",
+                "summary": "List moderation actions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Chat ID",
-                        "name": "chatID",
-                        "in": "path",
-                        "required": true
+                        "description": "Only actions taken against this user",
+                        "name": "user_id",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
                         "default": 50,
-                        "description": "Number of messages per page (max 100)",
+                        "description": "Number of actions to return (max 100)",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "integer",
                         "default": 0,
-                        "description": "Offset for pagination",
+                        "description": "Number of actions to skip",
                         "name": "offset",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Messages retrieved successfully",
+                        "description": "Moderation actions retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -402,15 +415,15 @@ const docTemplate = `{
                                         "data": {
                                             "type": "object",
                                             "properties": {
-                                                "limit": {
-                                                    "type": "integer"
-                                                },
-                                                "messages": {
+                                                "actions": {
                                                     "type": "array",
                                                     "items": {
-                                                        "$ref": "#/definitions/models.Message"
+                                                        "$ref": "#/definitions/models.ModerationAction"
                                                     }
                                                 },
+                                                "limit": {
+                                                    "type": "integer"
+                                                },
                                                 "offset": {
                                                     "type": "integer"
                                                 },
@@ -424,12 +437,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - invalid chat ID",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
@@ -437,13 +444,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - no access to chat",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Chat not found",
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -455,14 +456,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send a message in a chat conversation",
+                "description": "Look up users by email or name (moderator or admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -470,30 +473,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
+                    "Admin"
                 ],
-                "summary": "Send message",
+                "summary": "Search users",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Chat ID",
-                        "name": "chatID",
-                        "in": "path",
-                        "required": true
+                        "description": "Email or name to search for",
+                        "name": "q",
+                        "in": "query"
                     },
                     {
-                        "description": "Message data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.MessageRequest"
-                        }
+                        "enum": [
+                            "user",
+                            "moderator",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Filter by role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return suspended users",
+                        "name": "suspended",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of users to return (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Message sent successfully",
+                    "200": {
+                        "description": "Users retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -503,19 +527,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Message"
+                                            "type": "object",
+                                            "properties": {
+                                                "limit": {
+                                                    "type": "integer"
+                                                },
+                                                "offset": {
+                                                    "type": "integer"
+                                                },
+                                                "total": {
+                                                    "type": "integer"
+                                                },
+                                                "users": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.User"
+                                                    }
+                                                }
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - validation failed",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
@@ -523,7 +558,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - no access to chat",
+                        "description": "Insufficient permissions",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -537,14 +572,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/images": {
+        "/admin/users/{userID}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all images uploaded by the current user",
+                "description": "Get a user's account, role and moderation state (moderator or admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -552,12 +587,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Images"
+                    "Admin"
+                ],
+                "summary": "Get user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List user images",
                 "responses": {
                     "200": {
-                        "description": "Images retrieved successfully",
+                        "description": "User retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -567,18 +611,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "images": {
-                                                    "type": "array",
-                                                    "items": {
-                                                        "$ref": "#/definitions/models.Image"
-                                                    }
-                                                },
-                                                "total": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/models.User"
                                         }
                                     }
                                 }
@@ -591,6 +624,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -600,42 +645,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/images/download/{imageKey}": {
-            "get": {
+        "/admin/users/{userID}/role": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Download an image file owned by the user",
+                "description": "Change a user's role. The user's existing access tokens are revoked so the new role applies on their next refresh. (admin only)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/octet-stream"
+                    "application/json"
                 ],
                 "tags": [
-                    "Images"
+                    "Admin"
                 ],
-                "summary": "Download image",
+                "summary": "Update user role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "S3 image key",
-                        "name": "imageKey",
+                        "description": "User ID",
+                        "name": "userID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRoleRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Image file",
+                        "description": "User role updated successfully",
                         "schema": {
-                            "type": "file"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad request - missing image key",
+                        "description": "Bad request - validation failed",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -646,8 +712,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Image not found",
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -661,14 +733,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/images/presigned-upload": {
+        "/admin/users/{userID}/suspend": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate a presigned URL for direct image upload to S3",
+                "description": "Suspend a user for a number of hours, or indefinitely when no duration is given. All of the user's access tokens stop working immediately. (moderator or admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -676,23 +748,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Images"
+                    "Admin"
                 ],
-                "summary": "Generate presigned upload URL",
+                "summary": "Suspend user",
                 "parameters": [
                     {
-                        "description": "Presigned upload request",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.PresignedUploadRequest"
+                            "$ref": "#/definitions/models.SuspendUserRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Presigned URL generated successfully",
+                        "description": "User suspended successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -702,7 +781,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.PresignedUploadResponse"
+                                            "$ref": "#/definitions/models.User"
                                         }
                                     }
                                 }
@@ -710,7 +789,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid content type",
+                        "description": "Bad request - validation failed",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -721,6 +800,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -730,43 +821,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/images/upload": {
+        "/admin/users/{userID}/unsuspend": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload an image file to user's profile",
+                "description": "Lift a user's suspension (moderator or admin only)",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Images"
+                    "Admin"
                 ],
-                "summary": "Upload image",
+                "summary": "Unsuspend user",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Image file (JPEG/PNG/WebP, max 10MB)",
-                        "name": "image",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userID",
+                        "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Image position (1-9)",
-                        "name": "position",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Image uploaded successfully",
+                    "200": {
+                        "description": "User unsuspended successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -776,21 +860,27 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ImageUploadResponse"
+                                            "$ref": "#/definitions/models.User"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - invalid file",
+                    "401": {
+                        "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid token",
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -804,14 +894,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/images/upload-base64": {
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Upload an image from base64 encoded data",
+                "description": "Authenticate user with email and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -819,23 +904,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Images"
+                    "Authentication"
                 ],
-                "summary": "Upload base64 image",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "description": "Base64 image data",
+                        "description": "User login credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.ImageUploadBase64Request"
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Base64 image uploaded successfully",
+                    "200": {
+                        "description": "Login successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -845,7 +930,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.ImageUploadResponse"
+                                            "$ref": "#/definitions/models.AuthResponse"
                                         }
                                     }
                                 }
@@ -853,13 +938,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid base64 data",
+                        "description": "Bad request - validation failed",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid token",
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts or account temporarily locked",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -873,14 +970,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/images/{imageKey}": {
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete an image owned by the user",
+        "/auth/logout": {
+            "post": {
+                "description": "Logout user by invalidating refresh token and clearing session. If an access token is sent in the Authorization header it is revoked immediately.",
                 "consumes": [
                     "application/json"
                 ],
@@ -888,45 +980,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Images"
+                    "Authentication"
                 ],
-                "summary": "Delete image",
+                "summary": "User logout",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "S3 image key",
-                        "name": "imageKey",
-                        "in": "path",
-                        "required": true
+                        "description": "Refresh token to invalidate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Image deleted successfully",
+                        "description": "Logout successful",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request - missing image key",
+                        "description": "Bad request - validation failed",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid token",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Image not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -934,14 +1016,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/matches": {
-            "get": {
+        "/auth/logout-all": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve user's current matches with pagination",
+                "description": "Revoke every session of the current user, including the current one. All access tokens issued before the request stop working immediately.",
                 "consumes": [
                     "application/json"
                 ],
@@ -949,28 +1031,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Matching"
-                ],
-                "summary": "Get user matches",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of matches per page (max 100)",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Authentication"
                 ],
+                "summary": "Log out everywhere",
                 "responses": {
                     "200": {
-                        "description": "Matches retrieved successfully",
+                        "description": "Logged out of all sessions",
                         "schema": {
                             "allOf": [
                                 {
@@ -982,19 +1048,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "object",
                                             "properties": {
-                                                "limit": {
-                                                    "type": "integer"
-                                                },
-                                                "matches": {
-                                                    "type": "array",
-                                                    "items": {
-                                                        "$ref": "#/definitions/models.Match"
-                                                    }
-                                                },
-                                                "page": {
-                                                    "type": "integer"
-                                                },
-                                                "total": {
+                                                "sessions_revoked": {
                                                     "type": "integer"
                                                 }
                                             }
@@ -1004,12 +1058,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - invalid pagination",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
@@ -1025,14 +1073,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/matches/potential": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve potential matches based on user preferences and compatibility",
+        "/auth/refresh": {
+            "post": {
+                "description": "Generate new access token using refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -1040,21 +1083,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Matching"
+                    "Authentication"
                 ],
-                "summary": "Get potential matches",
+                "summary": "Refresh access token",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Number of potential matches (max 50)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RefreshTokenRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Potential matches found",
+                        "description": "Token refreshed successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1064,10 +1109,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/models.User"
-                                            }
+                                            "$ref": "#/definitions/models.AuthResponse"
                                         }
                                     }
                                 }
@@ -1075,13 +1117,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid parameters",
+                        "description": "Bad request - validation failed",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid token",
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Account suspended",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1095,14 +1143,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/matches/swipe": {
+        "/auth/register": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Perform a swipe action (like/pass/super like) on another user",
+                "description": "Register a new user account with email and password",
                 "consumes": [
                     "application/json"
                 ],
@@ -1110,23 +1153,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Matching"
+                    "Authentication"
                 ],
-                "summary": "Swipe on a user",
+                "summary": "Register a new user",
                 "parameters": [
                     {
-                        "description": "Swipe action data",
+                        "description": "User registration data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SwipeRequest"
+                            "$ref": "#/definitions/models.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all devices the current user is logged in on",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
                 ],
+                "summary": "List active sessions",
                 "responses": {
                     "200": {
-                        "description": "Swipe recorded successfully",
+                        "description": "Sessions retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1138,14 +1233,14 @@ const docTemplate = `{
                                         "data": {
                                             "type": "object",
                                             "properties": {
-                                                "is_match": {
-                                                    "type": "boolean"
-                                                },
-                                                "match_id": {
-                                                    "type": "string"
+                                                "sessions": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.Session"
+                                                    }
                                                 },
-                                                "swipe": {
-                                                    "$ref": "#/definitions/models.Swipe"
+                                                "total": {
+                                                    "type": "integer"
                                                 }
                                             }
                                         }
@@ -1154,12 +1249,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - validation failed or already swiped",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
@@ -1171,18 +1260,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Session store not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/matches/{matchID}": {
+        "/auth/sessions/{sessionID}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove/unmatch with another user",
+                "description": "Log out a specific device by revoking its session and refresh token",
                 "consumes": [
                     "application/json"
                 ],
@@ -1190,39 +1285,33 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Matching"
+                    "Authentication"
                 ],
-                "summary": "Remove a match",
+                "summary": "Revoke a session",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Match ID",
-                        "name": "matchID",
+                        "description": "Session ID",
+                        "name": "sessionID",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Match removed successfully",
+                        "description": "Session revoked successfully",
                         "schema": {
                             "$ref": "#/definitions/models.APIResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad request - invalid match ID",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - not part of this match",
+                    "404": {
+                        "description": "Session not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1232,18 +1321,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Session store not available",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/notifications": {
-            "get": {
+        "/boosts": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve user's notifications with pagination and filtering",
+                "description": "Start a boost that ranks the current user higher in nearby users' discovery decks for a fixed window. Only one boost can run at a time.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1251,34 +1346,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
-                ],
-                "summary": "Get user notifications",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of notifications per page (max 50)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by notification type",
-                        "name": "type",
-                        "in": "query"
-                    }
+                    "Boosts"
                 ],
+                "summary": "Activate boost",
                 "responses": {
-                    "200": {
-                        "description": "Notifications retrieved successfully",
+                    "201": {
+                        "description": "Boost activated",
                         "schema": {
                             "allOf": [
                                 {
@@ -1288,40 +1361,35 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "limit": {
-                                                    "type": "integer"
-                                                },
-                                                "notifications": {
-                                                    "type": "array",
-                                                    "items": {
-                                                        "$ref": "#/definitions/models.Notification"
-                                                    }
-                                                },
-                                                "page": {
-                                                    "type": "integer"
-                                                },
-                                                "total": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/models.Boost"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - invalid pagination",
+                    "401": {
+                        "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - invalid token",
+                    "409": {
+                        "description": "A boost is already active",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Boost"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
@@ -1333,14 +1401,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/devices": {
-            "post": {
+        "/boosts/active": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Register a device token to receive push notifications",
+                "description": "Get the current user's running boost with its stats so far, and the most recent finished boost with its final stats. Stats compare impressions, counted as swipes received, and likes with what the user got in a window of the same length during the week before the boost.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1348,23 +1416,12 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
-                ],
-                "summary": "Register device for push notifications",
-                "parameters": [
-                    {
-                        "description": "Device registration data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RegisterDeviceRequest"
-                        }
-                    }
+                    "Boosts"
                 ],
+                "summary": "Get active boost",
                 "responses": {
-                    "201": {
-                        "description": "Device registered successfully",
+                    "200": {
+                        "description": "Boost status retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -1374,19 +1431,13 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.DeviceToken"
+                                            "$ref": "#/definitions/models.BoostStatus"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - validation failed",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
@@ -1402,14 +1453,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/devices/{tokenID}": {
-            "delete": {
+        "/chats": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a device token from receiving push notifications",
+                "description": "Retrieve user's chat conversations with pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -1417,27 +1468,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Chat"
                 ],
-                "summary": "Unregister device from push notifications",
+                "summary": "Get user chats",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Device token ID",
-                        "name": "tokenID",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of chats per page (max 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Device unregistered from notifications",
+                        "description": "Chats retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "chats": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.Chat"
+                                                    }
+                                                },
+                                                "limit": {
+                                                    "type": "integer"
+                                                },
+                                                "page": {
+                                                    "type": "integer"
+                                                },
+                                                "total": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad request - missing token ID",
+                        "description": "Bad request - invalid pagination",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1448,12 +1535,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Device token not found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1463,27 +1544,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/preferences": {
+        "/chats/gifs/search": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve the current user's notification preferences",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Search GIFs to send in a chat. Results come from the configured GIF provider, are cached briefly and never exceed the server's maximum content rating. Send one by passing its id as gif_id.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Chat"
+                ],
+                "summary": "Search GIFs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search terms",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Highest content rating to include: g, pg, pg-13 or r (capped at the server maximum)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 25,
+                        "description": "Number of GIFs (max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination, from next_offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get notification preferences",
                 "responses": {
                     "200": {
-                        "description": "Notification preferences retrieved",
+                        "description": "GIFs retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1493,69 +1600,98 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "preferences": {
-                                                    "$ref": "#/definitions/models.NotificationPreferences"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/models.GIFPage"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request - invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "502": {
+                        "description": "GIF provider unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "GIF search not configured",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/chats/gifs/trending": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the current user's notification preferences",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get currently trending GIFs from the configured GIF provider, cached briefly and never exceeding the server's maximum content rating.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Chat"
                 ],
-                "summary": "Update notification preferences",
+                "summary": "Trending GIFs",
                 "parameters": [
                     {
-                        "description": "Notification preferences update data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateNotificationPreferencesRequest"
-                        }
+                        "type": "string",
+                        "description": "Highest content rating to include: g, pg, pg-13 or r (capped at the server maximum)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 25,
+                        "description": "Number of GIFs (max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination, from next_offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notification preferences updated",
+                        "description": "GIFs retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.GIFPage"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad request - validation failed",
+                        "description": "Bad request - invalid query",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1566,14 +1702,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Notification preferences not found",
+                    "502": {
+                        "description": "GIF provider unavailable",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "503": {
+                        "description": "GIF search not configured",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1581,27 +1717,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/read-all": {
-            "put": {
+        "/chats/{chatID}/attachments": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark all notifications as read for the current user",
+                "description": "Upload a JPEG, PNG or GIF image to a chat, then send it by passing the returned id as attachment_id to POST /chats/{chatID}/messages or a send_message WebSocket frame. Attachments are private to the two match participants: url and thumbnail_url are signed and expire after an hour, and GET /chats/{chatID}/attachments/{attachmentID} returns fresh ones.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Chat"
+                ],
+                "summary": "Upload chat attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file (JPEG/PNG/GIF, max 10MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
-                "summary": "Mark all notifications as read",
                 "responses": {
-                    "200": {
-                        "description": "All notifications marked as read",
+                    "201": {
+                        "description": "Attachment uploaded",
                         "schema": {
                             "allOf": [
                                 {
@@ -1611,24 +1763,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "notifications_updated": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/models.ChatAttachment"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request - invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Chat not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1638,27 +1797,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/test": {
-            "post": {
+        "/chats/{chatID}/attachments/{attachmentID}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Send a test notification to verify notification setup",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get a chat attachment with freshly signed url and thumbnail_url, for when earlier ones expired. Only the two match participants can fetch attachments that were sent, and only the uploader those that weren't yet.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Chat"
+                ],
+                "summary": "Get chat attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Send test notification",
                 "responses": {
                     "200": {
-                        "description": "Test notification sent",
+                        "description": "Attachment retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -1668,27 +1840,31 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "properties": {
-                                                "message": {
-                                                    "type": "string"
-                                                },
-                                                "notification_id": {
-                                                    "type": "string"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/models.ChatAttachment"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request - invalid attachment ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1698,14 +1874,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/unread-count": {
+        "/chats/{chatID}/messages": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the count of unread notifications for the current user",
+                "description": "Retrieve messages for a specific chat conversation with pagination, newest first. With since_seq, messages after that sequence number are returned oldest first instead, for catching up after a reconnect; page on by passing the last seq received until it reaches last_seq. With changed_since_seq, messages edited, unsent or reacted to after that change sequence number are returned in the order they changed; page on by passing the last change_seq received until it reaches last_change_seq. Fetching marks the other user's messages as delivered; use POST /chats/{chatID}/read to mark them read. On the caller's own messages is_read and read_at tell whether the other user has read them, unless they turned read receipts off.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1713,12 +1889,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Chat"
+                ],
+                "summary": "Get chat messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Number of messages per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return messages after this sequence number, oldest first (offset is ignored)",
+                        "name": "since_seq",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return messages changed after this change sequence number, in the order they changed (offset is ignored)",
+                        "name": "changed_since_seq",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get unread notification count",
                 "responses": {
                     "200": {
-                        "description": "Unread count retrieved successfully",
+                        "description": "Messages retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1730,7 +1941,25 @@ const docTemplate = `{
                                         "data": {
                                             "type": "object",
                                             "properties": {
-                                                "unread_count": {
+                                                "last_change_seq": {
+                                                    "type": "integer"
+                                                },
+                                                "last_seq": {
+                                                    "type": "integer"
+                                                },
+                                                "limit": {
+                                                    "type": "integer"
+                                                },
+                                                "messages": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.Message"
+                                                    }
+                                                },
+                                                "offset": {
+                                                    "type": "integer"
+                                                },
+                                                "total": {
                                                     "type": "integer"
                                                 }
                                             }
@@ -1740,12 +1969,30 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request - invalid chat ID, or since_seq combined with changed_since_seq",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - no access to chat",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Chat not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1753,16 +2000,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notifications/{notificationID}/read": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark a specific notification as read for the current user",
+                "description": "Send a message in a chat conversation. Clients can pass a client_message_id of their choosing; retrying with the same ID returns the message already stored instead of sending it twice. To send an image, upload it with POST /chats/{chatID}/attachments and pass its attachment_id; content is then an optional caption and the message type follows the image.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1770,27 +2015,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Notifications"
+                    "Chat"
                 ],
-                "summary": "Mark notification as read",
+                "summary": "Send message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Notification ID",
-                        "name": "notificationID",
+                        "description": "Chat ID",
+                        "name": "chatID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Message data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Notification marked as read",
+                        "description": "Message already sent",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "message": {
+                                                    "$ref": "#/definitions/models.Message"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Message sent successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "message": {
+                                                    "$ref": "#/definitions/models.Message"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad request - missing notification ID",
+                        "description": "Bad request - validation failed",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1802,7 +2096,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Notification not found",
+                        "description": "Chat not found or access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1816,43 +2110,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/photos": {
-            "post": {
+        "/chats/{chatID}/messages/{messageID}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload a new photo to user profile",
+                "description": "Replace the content of one of the caller's text messages, within 15 minutes of sending it. The previous content is kept in the message's edit history and both chat members are sent a message_edited event over WebSocket.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Chat"
                 ],
-                "summary": "Upload profile photo",
+                "summary": "Edit message",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Photo file (JPEG/PNG, max 5MB)",
-                        "name": "photo",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Photo position (1-9)",
-                        "name": "position",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New content",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EditMessageRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Photo uploaded successfully",
+                    "200": {
+                        "description": "Message edited",
                         "schema": {
                             "allOf": [
                                 {
@@ -1862,7 +2165,12 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.Photo"
+                                            "type": "object",
+                                            "properties": {
+                                                "message": {
+                                                    "$ref": "#/definitions/models.Message"
+                                                }
+                                            }
                                         }
                                     }
                                 }
@@ -1870,7 +2178,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid file",
+                        "description": "Bad request - validation failed or not a text message",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1881,6 +2189,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Not the sender, or the edit window has passed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Chat or message not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Message was unsent",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -1888,44 +2214,63 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/photos/{photoID}": {
+            },
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a specific photo from user profile",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Unsend one of the caller's messages for everyone. The message stays in the chat as a tombstone with deleted_at set and its content cleared; its edit history, reactions and attachment are removed. Both chat members are sent a message_deleted event over WebSocket. Unsending a message again returns the tombstone.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Chat"
                 ],
-                "summary": "Delete profile photo",
+                "summary": "Unsend message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Photo ID",
-                        "name": "photoID",
+                        "description": "Chat ID",
+                        "name": "chatID",
                         "in": "path",
                         "required": true
-                    }
-                ],
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Photo deleted successfully",
+                        "description": "Message unsent",
                         "schema": {
-                            "$ref": "#/definitions/models.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "message": {
+                                                    "$ref": "#/definitions/models.Message"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid photo ID",
+                        "description": "Bad request - invalid message ID",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1937,7 +2282,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - photo doesn't belong to user",
+                        "description": "Not the sender",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Chat or message not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1951,27 +2302,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/preferences": {
+        "/chats/{chatID}/messages/{messageID}/edits": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve the current user's matching preferences",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the earlier versions of an edited message, most recent first. Unsent messages have no history.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Chat"
+                ],
+                "summary": "Get message edit history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user preferences",
                 "responses": {
                     "200": {
-                        "description": "Preferences retrieved successfully",
+                        "description": "Edit history retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -1981,19 +2345,39 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserPrefs"
+                                            "type": "object",
+                                            "properties": {
+                                                "edits": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.MessageEdit"
+                                                    }
+                                                }
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad request - invalid message ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Chat or message not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2001,14 +2385,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/chats/{chatID}/messages/{messageID}/reaction": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the current user's matching preferences",
+                "description": "Set the caller's emoji reaction to a message in one of their chats, replacing any earlier reaction of theirs. Both chat members are sent a reaction_added event over WebSocket.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2016,23 +2402,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Chat"
                 ],
-                "summary": "Update user preferences",
+                "summary": "React to message",
                 "parameters": [
                     {
-                        "description": "Preferences update data",
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reaction",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdatePreferencesRequest"
+                            "$ref": "#/definitions/models.ReactionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Preferences updated successfully",
+                        "description": "Reaction saved",
                         "schema": {
                             "allOf": [
                                 {
@@ -2042,7 +2442,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.UserPrefs"
+                                            "$ref": "#/definitions/models.MessageReaction"
                                         }
                                     }
                                 }
@@ -2061,6 +2461,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Chat or message not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Message was unsent",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2068,43 +2480,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users/profile": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve the current user's profile information",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove the caller's reaction to a message. Both chat members are sent a reaction_removed event over WebSocket.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Chat"
+                ],
+                "summary": "Remove reaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "messageID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "Profile retrieved successfully",
+                        "description": "Reaction removed",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/models.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/models.User"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid message ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
                     "401": {
@@ -2114,7 +2531,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Chat, message or reaction not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -2126,14 +2543,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/chats/{chatID}/read": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the current user's profile information",
+                "description": "Mark the other user's messages in a chat as read, up to and including the given message. The sender is sent a read event over WebSocket unless the caller turned read receipts off with the send_read_receipts preference; the messages count as read for the caller either way.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2141,23 +2560,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Management"
+                    "Chat"
                 ],
-                "summary": "Update user profile",
+                "summary": "Mark chat read",
                 "parameters": [
                     {
-                        "description": "Profile update data",
+                        "type": "string",
+                        "description": "Chat ID",
+                        "name": "chatID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Last message read",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateProfileRequest"
+                            "$ref": "#/definitions/models.MarkReadRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully",
+                        "description": "Chat marked read",
                         "schema": {
                             "allOf": [
                                 {
@@ -2167,7 +2593,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.User"
+                                            "$ref": "#/definitions/models.ChatReadState"
                                         }
                                     }
                                 }
@@ -2186,6 +2612,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Chat or message not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -2195,9 +2627,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/ws": {
+        "/images": {
             "get": {
-                "description": "Establish WebSocket connection for real-time chat and notifications",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all images uploaded by the current user",
                 "consumes": [
                     "application/json"
                 ],
@@ -2205,89 +2642,2805 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chat"
-                ],
-                "summary": "WebSocket connection",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID for WebSocket connection",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "Images"
                 ],
+                "summary": "List user images",
                 "responses": {
-                    "101": {
-                        "description": "WebSocket connection established",
+                    "200": {
+                        "description": "Images retrieved successfully",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "images": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.Image"
+                                                    }
+                                                },
+                                                "total": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Bad request - missing user_id",
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.APIResponse": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "error": {
-                    "type": "string"
-                },
-                "message": {
-                    "type": "string"
-                },
-                "success": {
-                    "type": "boolean"
-                }
-            }
         },
-        "models.AuthResponse": {
+        "/images/download/{imageKey}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download an image file owned by the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Download image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "S3 image key",
+                        "name": "imageKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - missing image key",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/presigned-upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a presigned URL for direct image upload to S3",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Generate presigned upload URL",
+                "parameters": [
+                    {
+                        "description": "Presigned upload request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PresignedUploadRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Presigned URL generated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.PresignedUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid content type",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an image file to user's profile",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Upload image",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Image file (JPEG/PNG/WebP, max 10MB)",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Image position (1-9)",
+                        "name": "position",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Image uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImageUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/upload-base64": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an image from base64 encoded data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Upload base64 image",
+                "parameters": [
+                    {
+                        "description": "Base64 image data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ImageUploadBase64Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Base64 image uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ImageUploadResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid base64 data",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/{imageKey}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an image owned by the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Delete image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "S3 image key",
+                        "name": "imageKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - missing image key",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Image not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve user's current matches, most recently active first (last message, or match time for new matches). Pass next_cursor from the previous page as cursor to continue. Filter by new matches without messages or by conversations. When match expiry is enabled, matches nobody has written in yet include expires_at.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Get user matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of matches per page (max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "new",
+                            "conversations"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Which matches to list",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matches retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.MatchesPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid pagination or filter",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/likes-received": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get pending likes and super likes on the current user that have not been answered yet. Super likes come first, then the newest likes. Users whose plan includes seeing who likes them get full profiles; everyone else gets blurred previews without the liker's profile. Pass next_cursor from the previous page as cursor to continue.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Get likes received",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of likes per page (max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Likes retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.ReceivedLikesPage"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid cursor",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/likes-received/{likeID}/like-back": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Like back a user from the likes inbox. Since the other user already liked the current user this creates a match. Requires a plan that includes seeing who likes you; the like counts against the daily allowance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Like back",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the received like",
+                        "name": "likeID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Liked back successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "is_match": {
+                                                    "type": "boolean"
+                                                },
+                                                "match_id": {
+                                                    "type": "string"
+                                                },
+                                                "swipe": {
+                                                    "$ref": "#/definitions/models.Swipe"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - already answered",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Plan does not include seeing who likes you",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Like not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Daily like allowance used up",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/potential": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve potential matches based on user preferences and compatibility",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Get potential matches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of potential matches (max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Potential matches found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.User"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/quota": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get remaining daily likes and super likes and when they reset. Allowances depend on the subscription tier and reset at midnight in the user's timezone. A limit of -1 means unlimited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Get swipe quota",
+                "responses": {
+                    "200": {
+                        "description": "Swipe quota retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.SwipeQuota"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/rewind": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revert the most recent swipe if it was made within the rewind window and did not create a match. The profile returns to the top of the deck and any like or super like spent on it today is refunded. Each rewind counts against the daily rewind allowance.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Undo last swipe",
+                "responses": {
+                    "200": {
+                        "description": "Swipe undone",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "quota": {
+                                                    "$ref": "#/definitions/models.SwipeQuota"
+                                                },
+                                                "swipe": {
+                                                    "$ref": "#/definitions/models.Swipe"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No swipe to undo",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Swipe is too old, created a match or was already undone",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Daily rewind allowance used up",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/swipe": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Perform a swipe action (like/pass/super like) on another user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Swipe on a user",
+                "parameters": [
+                    {
+                        "description": "Swipe action data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SwipeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Swipe recorded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "is_match": {
+                                                    "type": "boolean"
+                                                },
+                                                "match_id": {
+                                                    "type": "string"
+                                                },
+                                                "swipe": {
+                                                    "$ref": "#/definitions/models.Swipe"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation failed or already swiped",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Daily like or super like allowance used up",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuotaExceededResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{matchID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unmatch with another user. The match is deactivated, its chat is archived so no more messages can be sent, and both users' clients receive an unmatched WebSocket event. An optional reason is kept for moderation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Remove a match",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Match ID",
+                        "name": "matchID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional unmatch reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.UnmatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Match removed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid match ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - not part of this match",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/matches/{matchID}/extend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Push back the expiry of a match nobody has written in yet. Each match can be extended once; the reminder is sent again a day before the new expiry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Matching"
+                ],
+                "summary": "Extend a match",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Match ID",
+                        "name": "matchID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Match extended",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Match"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Match not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Match does not expire or was already extended",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve user's notifications with pagination and filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get user notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of notifications per page (max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by notification type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notifications retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "limit": {
+                                                    "type": "integer"
+                                                },
+                                                "notifications": {
+                                                    "type": "array",
+                                                    "items": {
+                                                        "$ref": "#/definitions/models.Notification"
+                                                    }
+                                                },
+                                                "page": {
+                                                    "type": "integer"
+                                                },
+                                                "total": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid pagination",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/devices": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a device token to receive push notifications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Register device for push notifications",
+                "parameters": [
+                    {
+                        "description": "Device registration data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RegisterDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Device registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DeviceToken"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/devices/{tokenID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a device token from receiving push notifications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Unregister device from push notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device token ID",
+                        "name": "tokenID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Device unregistered from notifications",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - missing token ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Device token not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the current user's notification preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get notification preferences",
+                "responses": {
+                    "200": {
+                        "description": "Notification preferences retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "preferences": {
+                                                    "$ref": "#/definitions/models.NotificationPreferences"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the current user's notification preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Update notification preferences",
+                "parameters": [
+                    {
+                        "description": "Notification preferences update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateNotificationPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification preferences updated",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification preferences not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/read-all": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark all notifications as read for the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "All notifications marked as read",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "notifications_updated": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a test notification to verify notification setup",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Send test notification",
+                "responses": {
+                    "200": {
+                        "description": "Test notification sent",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "message": {
+                                                    "type": "string"
+                                                },
+                                                "notification_id": {
+                                                    "type": "string"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the count of unread notifications for the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Get unread notification count",
+                "responses": {
+                    "200": {
+                        "description": "Unread count retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "properties": {
+                                                "unread_count": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{notificationID}/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a specific notification as read for the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "notificationID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - missing notification ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule the current account for permanent deletion. The password must be re-entered. The account is hidden immediately and purged after a grace period, during which the deletion can be cancelled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Delete account",
+                "parameters": [
+                    {
+                        "description": "Password confirmation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account scheduled for deletion",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AccountDeletionStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token or password",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/deletion": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get when the current account is scheduled to be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Get pending account deletion",
+                "responses": {
+                    "200": {
+                        "description": "Account deletion pending",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.AccountDeletionStatus"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No account deletion pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a pending account deletion during the grace period and reactivate the account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Cancel account deletion",
+                "responses": {
+                    "200": {
+                        "description": "Account deletion cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No account deletion pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a ZIP archive of everything stored about the current user: profile, preferences, original photos, swipes, matches, messages with their edit history, reactions and sent images, notifications and analytics events. The user is notified when it is ready. If an export is already in progress it is returned instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Request data export",
+                "responses": {
+                    "200": {
+                        "description": "Data export already in progress",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DataExport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Data export queued",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DataExport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export/{exportID}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the status of a data export. Completed exports include a download link valid for a limited time; request the export again for a fresh link. Archives are deleted after their expiry.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Get data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "exportID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data export retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.DataExport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Data export not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/photos": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a new photo to user profile",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Upload profile photo",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Photo file (JPEG/PNG, max 5MB)",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Photo position (1-9)",
+                        "name": "position",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Photo uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Photo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/photos/{photoID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a specific photo from user profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Delete profile photo",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Photo ID",
+                        "name": "photoID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Photo deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid photo ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - photo doesn't belong to user",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the current user's matching preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Get user preferences",
+                "responses": {
+                    "200": {
+                        "description": "Preferences retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserPrefs"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the current user's matching preferences",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Update user preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preferences updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.UserPrefs"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the current user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the current user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Management"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "Profile update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/models.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Establish WebSocket connection for real-time chat and notifications",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat"
+                ],
+                "summary": "WebSocket connection",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "access_token, \u003ctoken\u003e for clients that can't set the Authorization header",
+                        "name": "Sec-WebSocket-Protocol",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "WebSocket connection established",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.AccountDeletionStatus": {
+            "type": "object",
+            "properties": {
+                "requested_at": {
+                    "type": "string"
+                },
+                "scheduled_for": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AgeGroup": {
+            "type": "object",
+            "properties": {
+                "age_range": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "user_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AppMetrics": {
+            "type": "object",
+            "properties": {
+                "active_users_24h": {
+                    "type": "integer"
+                },
+                "active_users_30d": {
+                    "type": "integer"
+                },
+                "active_users_7d": {
+                    "type": "integer"
+                },
+                "avg_session_length": {
+                    "type": "number"
+                },
+                "conversion_funnel": {
+                    "$ref": "#/definitions/models.ConversionFunnelMetrics"
+                },
+                "new_registrations_24h": {
+                    "type": "integer"
+                },
+                "total_matches_24h": {
+                    "type": "integer"
+                },
+                "total_messages_24h": {
+                    "type": "integer"
+                },
+                "total_swipes_24h": {
+                    "type": "integer"
+                },
+                "total_users": {
+                    "type": "integer"
+                },
+                "user_retention": {
+                    "$ref": "#/definitions/models.UserRetentionMetrics"
+                }
+            }
+        },
+        "models.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "seconds",
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.Boost": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "stats": {
+                    "$ref": "#/definitions/models.BoostStats"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BoostStats": {
+            "type": "object",
+            "properties": {
+                "expected_impressions": {
+                    "type": "integer"
+                },
+                "expected_likes": {
+                    "type": "integer"
+                },
+                "extra_impressions": {
+                    "type": "integer"
+                },
+                "extra_likes": {
+                    "type": "integer"
+                },
+                "impressions": {
+                    "description": "Swipes received, each a time the profile was seen",
+                    "type": "integer"
+                },
+                "likes": {
+                    "description": "Likes and super likes received",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BoostStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "$ref": "#/definitions/models.Boost"
+                },
+                "last_boost": {
+                    "description": "Most recent finished boost with final stats",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Boost"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.Chat": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_change_seq": {
+                    "description": "Change sequence number of the newest edit, unsend or reaction change, 0 if none",
+                    "type": "integer"
+                },
+                "last_message": {
+                    "$ref": "#/definitions/models.Message"
+                },
+                "last_message_at": {
+                    "type": "string"
+                },
+                "last_seq": {
+                    "description": "Sequence number of the newest message, 0 if none",
+                    "type": "integer"
+                },
+                "match_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ChatAttachment": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "chat_id": {
                     "type": "string"
                 },
-                "expires_in": {
-                    "description": "seconds",
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "height": {
                     "type": "integer"
                 },
-                "refresh_token": {
+                "id": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/models.User"
+                "message_id": {
+                    "description": "Empty until sent in a message",
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "uploader_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "url_expires_at": {
+                    "description": "When the signed URLs stop working",
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Chat": {
+        "models.ChatReadState": {
+            "type": "object",
+            "properties": {
+                "chat_id": {
+                    "type": "string"
+                },
+                "last_read_message_id": {
+                    "type": "string"
+                },
+                "marked_read": {
+                    "description": "Messages newly marked read",
+                    "type": "integer"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "receipts_sent": {
+                    "description": "False when the user has read receipts turned off",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.CityMetric": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "user_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ConversionFunnelMetrics": {
             "type": "object",
             "properties": {
+                "first_match": {
+                    "type": "integer"
+                },
+                "first_message": {
+                    "type": "integer"
+                },
+                "first_swipe": {
+                    "type": "integer"
+                },
+                "profile_complete": {
+                    "type": "integer"
+                },
+                "registrations": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CountryMetric": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "percentage": {
+                    "type": "number"
+                },
+                "user_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.DashboardMetrics": {
+            "type": "object",
+            "properties": {
+                "demographic": {
+                    "$ref": "#/definitions/models.DemographicMetrics"
+                },
+                "engagement": {
+                    "$ref": "#/definitions/models.EngagementMetrics"
+                },
+                "geographic": {
+                    "$ref": "#/definitions/models.GeographicMetrics"
+                },
+                "overview": {
+                    "$ref": "#/definitions/models.AppMetrics"
+                },
+                "revenue": {
+                    "$ref": "#/definitions/models.RevenueMetrics"
+                },
+                "trending": {
+                    "$ref": "#/definitions/models.TrendingMetrics"
+                }
+            }
+        },
+        "models.DataExport": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
-                "id": {
+                "download_url": {
+                    "description": "Presigned, only set when completed",
                     "type": "string"
                 },
-                "last_message": {
-                    "$ref": "#/definitions/models.Message"
+                "error": {
+                    "type": "string"
                 },
-                "last_message_at": {
+                "expires_at": {
                     "type": "string"
                 },
-                "match_id": {
+                "id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.DataExportStatus"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DataExportStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "processing",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "DataExportPending",
+                "DataExportProcessing",
+                "DataExportCompleted",
+                "DataExportFailed"
+            ]
+        },
+        "models.DataPoint": {
+            "type": "object",
+            "properties": {
+                "date": {
                     "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.DeleteAccountRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "models.DemographicMetrics": {
+            "type": "object",
+            "properties": {
+                "age_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AgeGroup"
+                    }
+                },
+                "gender_distribution": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GenderGroup"
+                    }
                 }
             }
         },
@@ -2310,25 +5463,251 @@ const docTemplate = `{
                     "description": "ios, android",
                     "type": "string"
                 },
-                "token": {
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EditMessageRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "models.EngagementMetrics": {
+            "type": "object",
+            "properties": {
+                "avg_matches_per_user": {
+                    "type": "number"
+                },
+                "avg_messages_per_user": {
+                    "type": "number"
+                },
+                "avg_sessions_per_user": {
+                    "type": "number"
+                },
+                "avg_swipes_per_user": {
+                    "type": "number"
+                },
+                "match_to_message_rate": {
+                    "type": "number"
+                },
+                "message_response_rate": {
+                    "type": "number"
+                },
+                "profile_completion_rate": {
+                    "type": "number"
+                },
+                "swipe_to_match_rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.EventSummary": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "event_type": {
+                    "$ref": "#/definitions/models.EventType"
+                },
+                "unique_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.EventType": {
+            "type": "string",
+            "enum": [
+                "user_login",
+                "user_logout",
+                "user_registered",
+                "profile_viewed",
+                "profile_updated",
+                "photo_uploaded",
+                "photo_deleted",
+                "account_locked",
+                "swipe_left",
+                "swipe_right",
+                "super_like",
+                "match_created",
+                "match_deleted",
+                "potential_viewed",
+                "message_sent",
+                "message_received",
+                "message_read",
+                "chat_opened",
+                "typing_started",
+                "app_opened",
+                "app_closed",
+                "screen_viewed",
+                "feature_used",
+                "search_performed",
+                "subscription_purchased",
+                "boost_purchased",
+                "super_like_purchased",
+                "error",
+                "exception"
+            ],
+            "x-enum-varnames": [
+                "EventUserLogin",
+                "EventUserLogout",
+                "EventUserRegistered",
+                "EventProfileViewed",
+                "EventProfileUpdated",
+                "EventPhotoUploaded",
+                "EventPhotoDeleted",
+                "EventAccountLocked",
+                "EventSwipeLeft",
+                "EventSwipeRight",
+                "EventSuperLike",
+                "EventMatchCreated",
+                "EventMatchDeleted",
+                "EventPotentialViewd",
+                "EventMessageSent",
+                "EventMessageReceived",
+                "EventMessageRead",
+                "EventChatOpened",
+                "EventTypingStarted",
+                "EventAppOpened",
+                "EventAppClosed",
+                "EventScreenViewed",
+                "EventFeatureUsed",
+                "EventSearchPerformed",
+                "EventSubscriptionPurchased",
+                "EventBoostPurchased",
+                "EventSuperLikePurchased",
+                "EventError",
+                "EventException"
+            ]
+        },
+        "models.FunnelAnalysis": {
+            "type": "object",
+            "properties": {
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FunnelStep"
+                    }
+                }
+            }
+        },
+        "models.FunnelStep": {
+            "type": "object",
+            "properties": {
+                "conversion_rate": {
+                    "type": "number"
+                },
+                "drop_off_rate": {
+                    "type": "number"
+                },
+                "step_name": {
+                    "type": "string"
+                },
+                "user_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GIF": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "Issued by the provider",
+                    "type": "string"
+                },
+                "preview_url": {
+                    "description": "Smaller rendition for pickers and previews",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "g, pg, pg-13 or r",
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 },
-                "user_id": {
+                "url": {
                     "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.GIFPage": {
             "type": "object",
             "properties": {
-                "code": {
+                "gifs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.GIF"
+                    }
+                },
+                "next_offset": {
+                    "description": "Zero when there are no more results",
                     "type": "integer"
                 },
-                "error": {
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GenderGroup": {
+            "type": "object",
+            "properties": {
+                "gender": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "percentage": {
+                    "type": "number"
+                },
+                "user_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.GeographicMetrics": {
+            "type": "object",
+            "properties": {
+                "top_cities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CityMetric"
+                    }
+                },
+                "top_countries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CountryMetric"
+                    }
                 }
             }
         },
@@ -2430,10 +5809,14 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "latitude": {
-                    "type": "number"
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
                 },
                 "longitude": {
-                    "type": "number"
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
                 },
                 "state": {
                     "type": "string"
@@ -2447,27 +5830,64 @@ const docTemplate = `{
                 "password"
             ],
             "properties": {
+                "device_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
                 "email": {
                     "type": "string"
                 },
                 "password": {
                     "type": "string",
                     "minLength": 8
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "ios",
+                        "android",
+                        "web"
+                    ]
+                }
+            }
+        },
+        "models.MarkReadRequest": {
+            "type": "object",
+            "required": [
+                "message_id"
+            ],
+            "properties": {
+                "message_id": {
+                    "type": "string"
                 }
             }
         },
         "models.Match": {
             "type": "object",
             "properties": {
+                "chat_id": {
+                    "description": "Conversation details, set when listing matches",
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "expires_at": {
+                    "description": "Set while match expiry is enabled and nobody has written yet",
+                    "type": "string"
+                },
+                "extended_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "is_active": {
                     "type": "boolean"
                 },
+                "last_message_at": {
+                    "type": "string"
+                },
                 "user1": {
                     "$ref": "#/definitions/models.User"
                 },
@@ -2482,18 +5902,96 @@ const docTemplate = `{
                 }
             }
         },
+        "models.MatchFilter": {
+            "type": "string",
+            "enum": [
+                "all",
+                "new",
+                "conversations"
+            ],
+            "x-enum-comments": {
+                "MatchFilterConversations": "At least one message",
+                "MatchFilterNew": "No messages yet"
+            },
+            "x-enum-descriptions": [
+                "",
+                "No messages yet",
+                "At least one message"
+            ],
+            "x-enum-varnames": [
+                "MatchFilterAll",
+                "MatchFilterNew",
+                "MatchFilterConversations"
+            ]
+        },
+        "models.MatchesPage": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/models.MatchFilter"
+                },
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Match"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Empty on the last page",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "All matches for the filter, across pages",
+                    "type": "integer"
+                }
+            }
+        },
         "models.Message": {
             "type": "object",
             "properties": {
+                "attachment": {
+                    "description": "Image or GIF sent with the message",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ChatAttachment"
+                        }
+                    ]
+                },
+                "change_seq": {
+                    "description": "Position of the latest edit, unsend or reaction change in the chat's changes",
+                    "type": "integer"
+                },
                 "chat_id": {
                     "type": "string"
                 },
+                "client_message_id": {
+                    "description": "Chosen by the sender's client to de-duplicate retries",
+                    "type": "string"
+                },
                 "content": {
                     "type": "string"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "description": "Set on unsent messages, whose content is cleared",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "gif": {
+                    "description": "GIF picked from the GIF provider",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GIF"
+                        }
+                    ]
+                },
                 "id": {
                     "type": "string"
                 },
@@ -2504,21 +6002,83 @@ const docTemplate = `{
                     "description": "text, image, gif",
                     "type": "string"
                 },
+                "reactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MessageReaction"
+                    }
+                },
+                "read_at": {
+                    "description": "Only set if the reader sends read receipts",
+                    "type": "string"
+                },
                 "sender_id": {
                     "type": "string"
+                },
+                "seq": {
+                    "description": "Position in the chat, counting up from 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MessageEdit": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Content before the edit",
+                    "type": "string"
+                },
+                "edited_at": {
+                    "description": "When it was replaced",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MessageReaction": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "emoji": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
         "models.MessageRequest": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
+                "attachment_id": {
+                    "description": "Uploaded through POST /chats/{chatID}/attachments; sets the message type",
+                    "type": "string"
+                },
+                "client_message_id": {
+                    "description": "Retrying with the same ID never sends twice",
+                    "type": "string",
+                    "maxLength": 64
+                },
                 "content": {
+                    "description": "Optional caption when sending an attachment or GIF",
                     "type": "string",
                     "maxLength": 500
                 },
+                "gif_id": {
+                    "description": "From GIF search; sends a gif message",
+                    "type": "string",
+                    "maxLength": 64
+                },
                 "message_type": {
                     "type": "string",
                     "enum": [
@@ -2529,6 +6089,69 @@ const docTemplate = `{
                 }
             }
         },
+        "models.ModerateImageRequest": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "approve",
+                        "remove"
+                    ]
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "models.ModerationAction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/models.ModerationActionType"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "moderator_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "target_user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ModerationActionType": {
+            "type": "string",
+            "enum": [
+                "suspend_user",
+                "unsuspend_user",
+                "change_role",
+                "approve_image",
+                "remove_image"
+            ],
+            "x-enum-varnames": [
+                "ModerationSuspendUser",
+                "ModerationUnsuspendUser",
+                "ModerationChangeRole",
+                "ModerationApproveImage",
+                "ModerationRemoveImage"
+            ]
+        },
         "models.Notification": {
             "type": "object",
             "properties": {
@@ -2609,6 +6232,7 @@ const docTemplate = `{
                 "photo_liked",
                 "account_verified",
                 "account_warning",
+                "data_export_ready",
                 "promotional"
             ],
             "x-enum-varnames": [
@@ -2621,6 +6245,7 @@ const docTemplate = `{
                 "NotificationPhotoLiked",
                 "NotificationAccountVerified",
                 "NotificationAccountWarning",
+                "NotificationDataExportReady",
                 "NotificationPromotional"
             ]
         },
@@ -2645,42 +6270,124 @@ const docTemplate = `{
                 }
             }
         },
-        "models.PresignedUploadRequest": {
+        "models.PresignedUploadRequest": {
+            "type": "object",
+            "required": [
+                "content_type"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer",
+                    "maximum": 9,
+                    "minimum": 1
+                }
+            }
+        },
+        "models.PresignedUploadResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "string"
+                },
+                "public_url": {
+                    "type": "string"
+                },
+                "s3_key": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "upload_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QuotaExceededResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/models.SwipeAction"
+                },
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "quota": {
+                    "$ref": "#/definitions/models.SwipeQuota"
+                },
+                "retry_after": {
+                    "description": "Seconds until the quota resets",
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.ReactionRequest": {
             "type": "object",
             "required": [
-                "content_type"
+                "emoji"
             ],
             "properties": {
-                "content_type": {
-                    "type": "string"
-                },
-                "position": {
-                    "type": "integer",
-                    "maximum": 9,
-                    "minimum": 1
+                "emoji": {
+                    "type": "string",
+                    "maxLength": 32
                 }
             }
         },
-        "models.PresignedUploadResponse": {
+        "models.ReceivedLike": {
             "type": "object",
             "properties": {
-                "expires_at": {
-                    "type": "string"
+                "action": {
+                    "$ref": "#/definitions/models.SwipeAction"
                 },
-                "image_id": {
-                    "type": "string"
+                "blurred": {
+                    "type": "boolean"
                 },
-                "public_url": {
+                "created_at": {
                     "type": "string"
                 },
-                "s3_key": {
+                "id": {
+                    "description": "ID of the liker's swipe",
                     "type": "string"
                 },
-                "thumbnail_url": {
-                    "type": "string"
+                "is_super_like": {
+                    "type": "boolean"
                 },
-                "upload_url": {
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                }
+            }
+        },
+        "models.ReceivedLikesPage": {
+            "type": "object",
+            "properties": {
+                "can_see": {
+                    "description": "Whether profiles are revealed",
+                    "type": "boolean"
+                },
+                "likes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReceivedLike"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Empty on the last page",
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
@@ -2731,6 +6438,10 @@ const docTemplate = `{
                     "maximum": 100,
                     "minimum": 18
                 },
+                "device_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
                 "email": {
                     "type": "string"
                 },
@@ -2755,6 +6466,119 @@ const docTemplate = `{
                 "password": {
                     "type": "string",
                     "minLength": 8
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "ios",
+                        "android",
+                        "web"
+                    ]
+                }
+            }
+        },
+        "models.RevenueMetrics": {
+            "type": "object",
+            "properties": {
+                "arpu": {
+                    "description": "Average Revenue Per User",
+                    "type": "number"
+                },
+                "churn_rate": {
+                    "type": "number"
+                },
+                "conversion_rate": {
+                    "type": "number"
+                },
+                "purchase_revenue": {
+                    "type": "number"
+                },
+                "subscription_revenue": {
+                    "type": "number"
+                },
+                "total_revenue_24h": {
+                    "type": "number"
+                },
+                "total_revenue_30d": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Role": {
+            "type": "string",
+            "enum": [
+                "user",
+                "moderator",
+                "admin"
+            ],
+            "x-enum-varnames": [
+                "RoleUser",
+                "RoleModerator",
+                "RoleAdmin"
+            ]
+        },
+        "models.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "device_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "platform": {
+                    "description": "ios, android, web",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SubscriptionTier": {
+            "type": "string",
+            "enum": [
+                "free",
+                "plus",
+                "premium"
+            ],
+            "x-enum-varnames": [
+                "TierFree",
+                "TierPlus",
+                "TierPremium"
+            ]
+        },
+        "models.SuspendUserRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "duration_hours": {
+                    "description": "omit for an indefinite suspension",
+                    "type": "integer",
+                    "maximum": 8760,
+                    "minimum": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 3
                 }
             }
         },
@@ -2801,6 +6625,50 @@ const docTemplate = `{
                 "SuperLike"
             ]
         },
+        "models.SwipeQuota": {
+            "type": "object",
+            "properties": {
+                "likes_limit": {
+                    "description": "-1 means unlimited",
+                    "type": "integer"
+                },
+                "likes_remaining": {
+                    "description": "-1 means unlimited",
+                    "type": "integer"
+                },
+                "likes_used": {
+                    "type": "integer"
+                },
+                "resets_at": {
+                    "description": "Next local midnight",
+                    "type": "string"
+                },
+                "rewinds_limit": {
+                    "type": "integer"
+                },
+                "rewinds_remaining": {
+                    "type": "integer"
+                },
+                "rewinds_used": {
+                    "type": "integer"
+                },
+                "super_likes_limit": {
+                    "type": "integer"
+                },
+                "super_likes_remaining": {
+                    "type": "integer"
+                },
+                "super_likes_used": {
+                    "type": "integer"
+                },
+                "tier": {
+                    "$ref": "#/definitions/models.SubscriptionTier"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
         "models.SwipeRequest": {
             "type": "object",
             "required": [
@@ -2825,6 +6693,44 @@ const docTemplate = `{
                 }
             }
         },
+        "models.TrendingMetrics": {
+            "type": "object",
+            "properties": {
+                "engagement": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DataPoint"
+                    }
+                },
+                "retention": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DataPoint"
+                    }
+                },
+                "revenue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DataPoint"
+                    }
+                },
+                "user_growth": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DataPoint"
+                    }
+                }
+            }
+        },
+        "models.UnmatchRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
         "models.UpdateNotificationPreferencesRequest": {
             "type": "object",
             "properties": {
@@ -2881,6 +6787,9 @@ const docTemplate = `{
                 "only_verified": {
                     "type": "boolean"
                 },
+                "send_read_receipts": {
+                    "type": "boolean"
+                },
                 "show_me": {
                     "type": "string",
                     "enum": [
@@ -2916,6 +6825,30 @@ const docTemplate = `{
                 },
                 "location": {
                     "$ref": "#/definitions/models.Location"
+                },
+                "timezone": {
+                    "description": "IANA name, used for daily resets",
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "enum": [
+                        "user",
+                        "moderator",
+                        "admin"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Role"
+                        }
+                    ]
                 }
             }
         },
@@ -2923,6 +6856,7 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "age": {
+                    "description": "Omitted on public profiles that hide their age",
                     "type": "integer"
                 },
                 "bio": {
@@ -2931,6 +6865,10 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "distance": {
+                    "description": "Distance bucket relative to the viewer",
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -2956,7 +6894,12 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "location": {
-                    "$ref": "#/definitions/models.Location"
+                    "description": "Never included in public profiles",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Location"
+                        }
+                    ]
                 },
                 "photos": {
                     "type": "array",
@@ -2967,11 +6910,84 @@ const docTemplate = `{
                 "preferences": {
                     "$ref": "#/definitions/models.UserPrefs"
                 },
+                "role": {
+                    "$ref": "#/definitions/models.Role"
+                },
+                "suspended_at": {
+                    "description": "Moderation state, only exposed through admin endpoints",
+                    "type": "string"
+                },
+                "suspended_until": {
+                    "type": "string"
+                },
+                "suspension_reason": {
+                    "type": "string"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "models.UserMetrics": {
+            "type": "object",
+            "properties": {
+                "active_matches": {
+                    "type": "integer"
+                },
+                "last_active_at": {
+                    "type": "string"
+                },
+                "left_swipes": {
+                    "type": "integer"
+                },
+                "match_rate": {
+                    "type": "number"
+                },
+                "message_response_rate": {
+                    "type": "number"
+                },
+                "messages_received": {
+                    "type": "integer"
+                },
+                "messages_sent": {
+                    "type": "integer"
+                },
+                "photos_uploaded": {
+                    "type": "integer"
+                },
+                "profile_views": {
+                    "type": "integer"
+                },
+                "registration_date": {
+                    "type": "string"
+                },
+                "right_swipes": {
+                    "type": "integer"
+                },
+                "session_count": {
+                    "type": "integer"
+                },
+                "super_likes": {
+                    "type": "integer"
+                },
+                "swipe_right_rate": {
+                    "type": "number"
+                },
+                "total_matches": {
+                    "type": "integer"
+                },
+                "total_swipes": {
+                    "type": "integer"
+                },
+                "total_time_spent": {
+                    "description": "in seconds",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UserPrefs": {
             "type": "object",
             "properties": {
@@ -3007,6 +7023,10 @@ const docTemplate = `{
                 "only_verified": {
                     "type": "boolean"
                 },
+                "send_read_receipts": {
+                    "description": "Let senders see when their messages were read",
+                    "type": "boolean"
+                },
                 "show_me": {
                     "type": "string"
                 },
@@ -3017,6 +7037,20 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "models.UserRetentionMetrics": {
+            "type": "object",
+            "properties": {
+                "day1_retention": {
+                    "type": "number"
+                },
+                "day30_retention": {
+                    "type": "number"
+                },
+                "day7_retention": {
+                    "type": "number"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"matching-api/pkg/geo"
+)
+
+var (
+	postGISOnce    sync.Once
+	postGISEnabled bool
+)
+
+// PostGISEnabled reports whether the users table has the PostGIS geography
+// column. The result is checked once and cached.
+func PostGISEnabled() bool {
+	if DB == nil {
+		return false
+	}
+
+	postGISOnce.Do(func() {
+		err := DB.QueryRow(`
+			SELECT EXISTS (
+				SELECT 1 FROM information_schema.columns
+				WHERE table_name = 'users' AND column_name = 'geog'
+			)
+		`).Scan(&postGISEnabled)
+		if err != nil {
+			log.Printf("Failed to detect PostGIS, using geohash indexing: %v", err)
+			postGISEnabled = false
+		}
+	})
+
+	return postGISEnabled
+}
+
+// BackfillGeohashes fills in the geohash of users whose location was stored
+// before geohash indexing existed and returns how many rows were updated
+func BackfillGeohashes() (int, error) {
+	if DB == nil {
+		return 0, fmt.Errorf("database connection not initialized")
+	}
+
+	updated := 0
+	for {
+		rows, err := DB.Query(`
+			SELECT id, latitude, longitude FROM users
+			WHERE geohash IS NULL AND latitude IS NOT NULL AND longitude IS NOT NULL
+			LIMIT 1000
+		`)
+		if err != nil {
+			return updated, fmt.Errorf("failed to query users without geohash: %v", err)
+		}
+
+		type location struct {
+			id       string
+			lat, lng float64
+		}
+		var batch []location
+		for rows.Next() {
+			var loc location
+			if err := rows.Scan(&loc.id, &loc.lat, &loc.lng); err != nil {
+				log.Printf("Error scanning user location: %v", err)
+				continue
+			}
+			batch = append(batch, loc)
+		}
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+		if len(batch) == 0 {
+			return updated, nil
+		}
+
+		for _, loc := range batch {
+			hash := geo.Encode(loc.lat, loc.lng, geo.MaxPrecision)
+			if _, err := DB.Exec(`UPDATE users SET geohash = $1 WHERE id = $2`, hash, loc.id); err != nil {
+				return updated, fmt.Errorf("failed to backfill geohash: %v", err)
+			}
+			updated++
+		}
+	}
+}
@@ -212,6 +212,336 @@ func GetMigrations() []Migration {
 			`,
 			Down: `DROP TABLE IF EXISTS analytics_events CASCADE;`,
 		},
+		{
+			Version: "011_add_roles_and_moderation",
+			Up: `
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'moderator', 'admin'));
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS suspension_reason TEXT;
+				CREATE INDEX IF NOT EXISTS idx_users_role ON users(role) WHERE role <> 'user';
+				CREATE INDEX IF NOT EXISTS idx_users_suspended ON users(suspended_at) WHERE suspended_at IS NOT NULL;
+
+				CREATE TABLE IF NOT EXISTS moderation_actions (
+					id UUID PRIMARY KEY,
+					moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+					target_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					action VARCHAR(50) NOT NULL,
+					reason TEXT,
+					metadata JSONB,
+					created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+				);
+				CREATE INDEX IF NOT EXISTS idx_moderation_actions_target ON moderation_actions(target_user_id);
+				CREATE INDEX IF NOT EXISTS idx_moderation_actions_moderator ON moderation_actions(moderator_id);
+				CREATE INDEX IF NOT EXISTS idx_moderation_actions_created_at ON moderation_actions(created_at);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS moderation_actions CASCADE;
+				DROP INDEX IF EXISTS idx_users_role;
+				DROP INDEX IF EXISTS idx_users_suspended;
+				ALTER TABLE users DROP COLUMN IF EXISTS suspension_reason;
+				ALTER TABLE users DROP COLUMN IF EXISTS suspended_until;
+				ALTER TABLE users DROP COLUMN IF EXISTS suspended_at;
+				ALTER TABLE users DROP COLUMN IF EXISTS role;
+			`,
+		},
+		{
+			Version: "012_add_account_deletion",
+			Up: `
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_for TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_reason TEXT;
+				CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled ON users(deletion_scheduled_for) WHERE deletion_scheduled_for IS NOT NULL;
+
+				CREATE TABLE IF NOT EXISTS account_deletion_receipts (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL,
+					email_hash VARCHAR(64) NOT NULL,
+					reason TEXT,
+					requested_at TIMESTAMP WITH TIME ZONE NOT NULL,
+					completed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+					storage_objects_deleted INTEGER NOT NULL DEFAULT 0,
+					analytics_events_anonymized BIGINT NOT NULL DEFAULT 0
+				);
+				CREATE INDEX IF NOT EXISTS idx_account_deletion_receipts_user_id ON account_deletion_receipts(user_id);
+				CREATE INDEX IF NOT EXISTS idx_account_deletion_receipts_email_hash ON account_deletion_receipts(email_hash);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS account_deletion_receipts CASCADE;
+				DROP INDEX IF EXISTS idx_users_deletion_scheduled;
+				ALTER TABLE users DROP COLUMN IF EXISTS deletion_reason;
+				ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_for;
+				ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
+			`,
+		},
+		{
+			Version: "013_create_data_exports_table",
+			Up: `
+				CREATE TABLE IF NOT EXISTS data_exports (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+					s3_key VARCHAR(500),
+					size BIGINT,
+					error TEXT,
+					created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+					completed_at TIMESTAMP WITH TIME ZONE,
+					expires_at TIMESTAMP WITH TIME ZONE
+				);
+				CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id, created_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports(status) WHERE status IN ('pending', 'processing');
+			`,
+			Down: `
+				DROP TABLE IF EXISTS data_exports CASCADE;
+			`,
+		},
+		{
+			Version: "014_add_geospatial_indexing",
+			Up: `
+				-- Geohash prefixes work on every installation; the application
+				-- fills them in when a location is saved
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS geohash VARCHAR(12);
+				CREATE INDEX IF NOT EXISTS idx_users_geohash ON users(geohash text_pattern_ops) WHERE is_active = true;
+
+				-- Use a PostGIS geography column when the extension can be installed
+				DO $$
+				BEGIN
+					IF NOT EXISTS (SELECT 1 FROM pg_available_extensions WHERE name = 'postgis') THEN
+						RAISE NOTICE 'PostGIS not available, using geohash indexing only';
+						RETURN;
+					END IF;
+
+					BEGIN
+						CREATE EXTENSION IF NOT EXISTS postgis;
+					EXCEPTION WHEN insufficient_privilege THEN
+						RAISE NOTICE 'Not allowed to install PostGIS, using geohash indexing only';
+						RETURN;
+					END;
+
+					ALTER TABLE users ADD COLUMN IF NOT EXISTS geog geography(Point, 4326);
+					UPDATE users SET geog = ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography
+					WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
+					UPDATE users SET geohash = ST_GeoHash(geog::geometry, 9)
+					WHERE geog IS NOT NULL AND geohash IS NULL;
+					CREATE INDEX IF NOT EXISTS idx_users_geog ON users USING GIST (geog);
+
+					CREATE OR REPLACE FUNCTION users_sync_geog() RETURNS trigger AS $fn$
+					BEGIN
+						IF NEW.latitude IS NULL OR NEW.longitude IS NULL THEN
+							NEW.geog := NULL;
+						ELSE
+							NEW.geog := ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326)::geography;
+						END IF;
+						RETURN NEW;
+					END;
+					$fn$ LANGUAGE plpgsql;
+
+					DROP TRIGGER IF EXISTS trg_users_sync_geog ON users;
+					CREATE TRIGGER trg_users_sync_geog
+						BEFORE INSERT OR UPDATE OF latitude, longitude ON users
+						FOR EACH ROW EXECUTE PROCEDURE users_sync_geog();
+				END
+				$$;
+			`,
+			Down: `
+				DROP TRIGGER IF EXISTS trg_users_sync_geog ON users;
+				DROP FUNCTION IF EXISTS users_sync_geog();
+				DROP INDEX IF EXISTS idx_users_geog;
+				ALTER TABLE users DROP COLUMN IF EXISTS geog;
+				DROP INDEX IF EXISTS idx_users_geohash;
+				ALTER TABLE users DROP COLUMN IF EXISTS geohash;
+			`,
+		},
+		{
+			Version: "015_add_swipe_quota_settings",
+			Up: `
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS subscription_tier VARCHAR(20) NOT NULL DEFAULT 'free' CHECK (subscription_tier IN ('free', 'plus', 'premium'));
+				ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+			`,
+			Down: `
+				ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+				ALTER TABLE users DROP COLUMN IF EXISTS subscription_tier;
+			`,
+		},
+		{
+			Version: "016_add_likes_inbox_index",
+			Up: `
+				CREATE INDEX IF NOT EXISTS idx_swipes_likes_inbox ON swipes(target_id, created_at DESC, id DESC) WHERE action IN ('right', 'super');
+			`,
+			Down: `DROP INDEX IF EXISTS idx_swipes_likes_inbox;`,
+		},
+		{
+			Version: "017_create_boosts_table",
+			Up: `
+				CREATE TABLE IF NOT EXISTS boosts (
+					id UUID PRIMARY KEY,
+					user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+					ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+					impressions INTEGER NOT NULL DEFAULT 0,
+					created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+					CHECK (ends_at > started_at)
+				);
+				CREATE INDEX IF NOT EXISTS idx_boosts_user_id ON boosts(user_id, started_at DESC);
+				CREATE INDEX IF NOT EXISTS idx_boosts_ends_at ON boosts(ends_at);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS boosts CASCADE;
+			`,
+		},
+		{
+			Version: "018_add_match_expiration",
+			Up: `
+				ALTER TABLE matches ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE matches ADD COLUMN IF NOT EXISTS extended_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE matches ADD COLUMN IF NOT EXISTS expiry_reminder_sent_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE matches ADD COLUMN IF NOT EXISTS expired_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE chats ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT true;
+				CREATE INDEX IF NOT EXISTS idx_matches_expires_at ON matches(expires_at) WHERE is_active = true AND expires_at IS NOT NULL;
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_matches_expires_at;
+				ALTER TABLE chats DROP COLUMN IF EXISTS is_active;
+				ALTER TABLE matches DROP COLUMN IF EXISTS expired_at;
+				ALTER TABLE matches DROP COLUMN IF EXISTS expiry_reminder_sent_at;
+				ALTER TABLE matches DROP COLUMN IF EXISTS extended_at;
+				ALTER TABLE matches DROP COLUMN IF EXISTS expires_at;
+			`,
+		},
+		{
+			Version: "019_add_unmatch_details",
+			Up: `
+				ALTER TABLE matches ADD COLUMN IF NOT EXISTS unmatched_by UUID REFERENCES users(id) ON DELETE SET NULL;
+				ALTER TABLE matches ADD COLUMN IF NOT EXISTS unmatch_reason TEXT;
+				ALTER TABLE matches ADD COLUMN IF NOT EXISTS unmatched_at TIMESTAMP WITH TIME ZONE;
+			`,
+			Down: `
+				ALTER TABLE matches DROP COLUMN IF EXISTS unmatched_at;
+				ALTER TABLE matches DROP COLUMN IF EXISTS unmatch_reason;
+				ALTER TABLE matches DROP COLUMN IF EXISTS unmatched_by;
+			`,
+		},
+		{
+			Version: "020_add_message_receipts",
+			Up: `
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS delivered_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS read_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS send_read_receipts BOOLEAN NOT NULL DEFAULT true;
+				UPDATE messages SET delivered_at = created_at, read_at = created_at WHERE is_read = true;
+				CREATE INDEX IF NOT EXISTS idx_messages_undelivered ON messages(chat_id) WHERE delivered_at IS NULL;
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_messages_undelivered;
+				ALTER TABLE user_preferences DROP COLUMN IF EXISTS send_read_receipts;
+				ALTER TABLE messages DROP COLUMN IF EXISTS read_at;
+				ALTER TABLE messages DROP COLUMN IF EXISTS delivered_at;
+			`,
+		},
+		{
+			Version: "021_add_message_client_ids",
+			Up: `
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS client_message_id VARCHAR(64);
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_client_message_id ON messages(sender_id, client_message_id) WHERE client_message_id IS NOT NULL;
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_messages_client_message_id;
+				ALTER TABLE messages DROP COLUMN IF EXISTS client_message_id;
+			`,
+		},
+		{
+			Version: "022_add_message_sequence_numbers",
+			Up: `
+				ALTER TABLE chats ADD COLUMN IF NOT EXISTS last_seq BIGINT NOT NULL DEFAULT 0;
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS seq BIGINT;
+				UPDATE messages SET seq = numbered.seq
+				FROM (
+					SELECT id, ROW_NUMBER() OVER (PARTITION BY chat_id ORDER BY created_at, id) AS seq
+					FROM messages
+				) numbered
+				WHERE messages.id = numbered.id;
+				UPDATE chats SET last_seq = COALESCE((SELECT MAX(seq) FROM messages WHERE messages.chat_id = chats.id), 0);
+				ALTER TABLE messages ALTER COLUMN seq SET NOT NULL;
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_chat_seq ON messages(chat_id, seq);
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_messages_chat_seq;
+				ALTER TABLE messages DROP COLUMN IF EXISTS seq;
+				ALTER TABLE chats DROP COLUMN IF EXISTS last_seq;
+			`,
+		},
+		{
+			Version: "023_add_message_edits_and_reactions",
+			Up: `
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP WITH TIME ZONE;
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+				CREATE TABLE IF NOT EXISTS message_edits (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+					content TEXT NOT NULL,
+					edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+				);
+				CREATE INDEX IF NOT EXISTS idx_message_edits_message_id ON message_edits(message_id, edited_at);
+				CREATE TABLE IF NOT EXISTS message_reactions (
+					message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+					user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					emoji VARCHAR(32) NOT NULL,
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+					PRIMARY KEY (message_id, user_id)
+				);
+				CREATE INDEX IF NOT EXISTS idx_message_reactions_user_id ON message_reactions(user_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS message_reactions;
+				DROP TABLE IF EXISTS message_edits;
+				ALTER TABLE messages DROP COLUMN IF EXISTS deleted_at;
+				ALTER TABLE messages DROP COLUMN IF EXISTS edited_at;
+			`,
+		},
+		{
+			Version: "024_add_chat_attachments",
+			Up: `
+				CREATE TABLE IF NOT EXISTS chat_attachments (
+					id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+					chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+					uploader_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+					message_id UUID UNIQUE REFERENCES messages(id) ON DELETE SET NULL,
+					s3_key VARCHAR(500) NOT NULL,
+					thumbnail_key VARCHAR(500) NOT NULL,
+					content_type VARCHAR(50) NOT NULL,
+					size BIGINT NOT NULL,
+					width INTEGER NOT NULL,
+					height INTEGER NOT NULL,
+					created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+				);
+				CREATE INDEX IF NOT EXISTS idx_chat_attachments_chat_id ON chat_attachments(chat_id);
+				CREATE INDEX IF NOT EXISTS idx_chat_attachments_uploader_id ON chat_attachments(uploader_id);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS chat_attachments;
+			`,
+		},
+		{
+			Version: "025_add_message_gifs",
+			Up: `
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS gif JSONB;
+			`,
+			Down: `
+				ALTER TABLE messages DROP COLUMN IF EXISTS gif;
+			`,
+		},
+		{
+			Version: "026_add_message_change_sequence",
+			Up: `
+				ALTER TABLE chats ADD COLUMN IF NOT EXISTS last_change_seq BIGINT NOT NULL DEFAULT 0;
+				ALTER TABLE messages ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT 0;
+				CREATE INDEX IF NOT EXISTS idx_messages_chat_change_seq ON messages(chat_id, change_seq) WHERE change_seq > 0;
+			`,
+			Down: `
+				DROP INDEX IF EXISTS idx_messages_chat_change_seq;
+				ALTER TABLE messages DROP COLUMN IF EXISTS change_seq;
+				ALTER TABLE chats DROP COLUMN IF EXISTS last_change_seq;
+			`,
+		},
 	}
 }
 
@@ -3,6 +3,13 @@
 -- Run this file to completely reset the database
 
 -- Drop tables in reverse dependency order
+DROP TABLE IF EXISTS chat_attachments CASCADE;
+DROP TABLE IF EXISTS message_reactions CASCADE;
+DROP TABLE IF EXISTS message_edits CASCADE;
+DROP TABLE IF EXISTS boosts CASCADE;
+DROP TABLE IF EXISTS data_exports CASCADE;
+DROP TABLE IF EXISTS account_deletion_receipts CASCADE;
+DROP TABLE IF EXISTS moderation_actions CASCADE;
 DROP TABLE IF EXISTS analytics_events CASCADE;
 DROP TABLE IF EXISTS images CASCADE;
 DROP TABLE IF EXISTS device_tokens CASCADE;
@@ -16,4 +23,6 @@ DROP TABLE IF EXISTS swipes CASCADE;
 DROP TABLE IF EXISTS user_preferences CASCADE;
 DROP TABLE IF EXISTS photos CASCADE;
 DROP TABLE IF EXISTS users CASCADE;
-DROP TABLE IF EXISTS migrations CASCADE;
\ No newline at end of file
+DROP TABLE IF EXISTS migrations CASCADE;
+-- Functions created by the optional PostGIS setup
+DROP FUNCTION IF EXISTS users_sync_geog() CASCADE;
@@ -18,6 +18,16 @@ CREATE TABLE IF NOT EXISTS users (
     country VARCHAR(100),
     is_active BOOLEAN DEFAULT true,
     last_seen TIMESTAMP WITH TIME ZONE,
+    role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'moderator', 'admin')),
+    suspended_at TIMESTAMP WITH TIME ZONE,
+    suspended_until TIMESTAMP WITH TIME ZONE,
+    suspension_reason TEXT,
+    deletion_requested_at TIMESTAMP WITH TIME ZONE,
+    deletion_scheduled_for TIMESTAMP WITH TIME ZONE,
+    deletion_reason TEXT,
+    geohash VARCHAR(12),
+    subscription_tier VARCHAR(20) NOT NULL DEFAULT 'free' CHECK (subscription_tier IN ('free', 'plus', 'premium')),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
@@ -28,6 +38,10 @@ CREATE INDEX IF NOT EXISTS idx_users_location ON users(latitude, longitude);
 CREATE INDEX IF NOT EXISTS idx_users_age ON users(age);
 CREATE INDEX IF NOT EXISTS idx_users_gender ON users(gender);
 CREATE INDEX IF NOT EXISTS idx_users_active ON users(is_active);
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role) WHERE role <> 'user';
+CREATE INDEX IF NOT EXISTS idx_users_suspended ON users(suspended_at) WHERE suspended_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled ON users(deletion_scheduled_for) WHERE deletion_scheduled_for IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_users_geohash ON users(geohash text_pattern_ops) WHERE is_active = true;
 
 -- Photos table
 CREATE TABLE IF NOT EXISTS photos (
@@ -55,6 +69,7 @@ CREATE TABLE IF NOT EXISTS user_preferences (
     only_verified BOOLEAN DEFAULT false,
     hide_distance BOOLEAN DEFAULT false,
     hide_age BOOLEAN DEFAULT false,
+    send_read_receipts BOOLEAN NOT NULL DEFAULT true,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     UNIQUE(user_id)
@@ -77,6 +92,7 @@ CREATE TABLE IF NOT EXISTS swipes (
 CREATE INDEX IF NOT EXISTS idx_swipes_user_id ON swipes(user_id);
 CREATE INDEX IF NOT EXISTS idx_swipes_target_id ON swipes(target_id);
 CREATE INDEX IF NOT EXISTS idx_swipes_action ON swipes(action);
+CREATE INDEX IF NOT EXISTS idx_swipes_likes_inbox ON swipes(target_id, created_at DESC, id DESC) WHERE action IN ('right', 'super');
 
 -- Matches table
 CREATE TABLE IF NOT EXISTS matches (
@@ -84,6 +100,13 @@ CREATE TABLE IF NOT EXISTS matches (
     user1_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     user2_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     is_active BOOLEAN DEFAULT true,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    extended_at TIMESTAMP WITH TIME ZONE,
+    expiry_reminder_sent_at TIMESTAMP WITH TIME ZONE,
+    expired_at TIMESTAMP WITH TIME ZONE,
+    unmatched_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    unmatch_reason TEXT,
+    unmatched_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     UNIQUE(user1_id, user2_id),
     CHECK (user1_id != user2_id)
@@ -93,12 +116,16 @@ CREATE TABLE IF NOT EXISTS matches (
 CREATE INDEX IF NOT EXISTS idx_matches_user1 ON matches(user1_id);
 CREATE INDEX IF NOT EXISTS idx_matches_user2 ON matches(user2_id);
 CREATE INDEX IF NOT EXISTS idx_matches_active ON matches(is_active);
+CREATE INDEX IF NOT EXISTS idx_matches_expires_at ON matches(expires_at) WHERE is_active = true AND expires_at IS NOT NULL;
 
 -- Chats table
 CREATE TABLE IF NOT EXISTS chats (
     id UUID PRIMARY KEY,
     match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
     last_message_at TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    last_change_seq BIGINT NOT NULL DEFAULT 0,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     UNIQUE(match_id)
@@ -116,6 +143,14 @@ CREATE TABLE IF NOT EXISTS messages (
     content TEXT NOT NULL,
     message_type VARCHAR(20) DEFAULT 'text' CHECK (message_type IN ('text', 'image', 'gif')),
     is_read BOOLEAN DEFAULT false,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    client_message_id VARCHAR(64),
+    seq BIGINT NOT NULL,
+    change_seq BIGINT NOT NULL DEFAULT 0,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    gif JSONB,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
@@ -124,6 +159,10 @@ CREATE INDEX IF NOT EXISTS idx_messages_chat_id ON messages(chat_id);
 CREATE INDEX IF NOT EXISTS idx_messages_sender ON messages(sender_id);
 CREATE INDEX IF NOT EXISTS idx_messages_created_at ON messages(created_at);
 CREATE INDEX IF NOT EXISTS idx_messages_unread ON messages(is_read) WHERE is_read = false;
+CREATE INDEX IF NOT EXISTS idx_messages_undelivered ON messages(chat_id) WHERE delivered_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_client_message_id ON messages(sender_id, client_message_id) WHERE client_message_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_chat_seq ON messages(chat_id, seq);
+CREATE INDEX IF NOT EXISTS idx_messages_chat_change_seq ON messages(chat_id, change_seq) WHERE change_seq > 0;
 
 -- Refresh tokens table
 CREATE TABLE IF NOT EXISTS refresh_tokens (
@@ -235,6 +274,112 @@ CREATE INDEX IF NOT EXISTS idx_images_position ON images(user_id, position);
 CREATE INDEX IF NOT EXISTS idx_images_s3_key ON images(s3_key);
 CREATE INDEX IF NOT EXISTS idx_images_active ON images(is_active);
 
+-- Moderation actions table
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id UUID PRIMARY KEY,
+    moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    target_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    reason TEXT,
+    metadata JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Moderation actions table indexes
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_target ON moderation_actions(target_user_id);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_moderator ON moderation_actions(moderator_id);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_created_at ON moderation_actions(created_at);
+
+-- Account deletion receipts table
+CREATE TABLE IF NOT EXISTS account_deletion_receipts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    email_hash VARCHAR(64) NOT NULL,
+    reason TEXT,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    storage_objects_deleted INTEGER NOT NULL DEFAULT 0,
+    analytics_events_anonymized BIGINT NOT NULL DEFAULT 0
+);
+
+-- Account deletion receipts table indexes
+CREATE INDEX IF NOT EXISTS idx_account_deletion_receipts_user_id ON account_deletion_receipts(user_id);
+CREATE INDEX IF NOT EXISTS idx_account_deletion_receipts_email_hash ON account_deletion_receipts(email_hash);
+
+-- Data exports table
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    s3_key VARCHAR(500),
+    size BIGINT,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Data exports table indexes
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports(status) WHERE status IN ('pending', 'processing');
+
+-- Boosts table
+CREATE TABLE IF NOT EXISTS boosts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    impressions INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (ends_at > started_at)
+);
+
+-- Boosts table indexes
+CREATE INDEX IF NOT EXISTS idx_boosts_user_id ON boosts(user_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_boosts_ends_at ON boosts(ends_at);
+
+-- Message edits table
+CREATE TABLE IF NOT EXISTS message_edits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Message edits table indexes
+CREATE INDEX IF NOT EXISTS idx_message_edits_message_id ON message_edits(message_id, edited_at);
+
+-- Message reactions table
+CREATE TABLE IF NOT EXISTS message_reactions (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id)
+);
+
+-- Message reactions table indexes
+CREATE INDEX IF NOT EXISTS idx_message_reactions_user_id ON message_reactions(user_id);
+
+-- Chat attachments table
+CREATE TABLE IF NOT EXISTS chat_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    uploader_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message_id UUID UNIQUE REFERENCES messages(id) ON DELETE SET NULL,
+    s3_key VARCHAR(500) NOT NULL,
+    thumbnail_key VARCHAR(500) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Chat attachments table indexes
+CREATE INDEX IF NOT EXISTS idx_chat_attachments_chat_id ON chat_attachments(chat_id);
+CREATE INDEX IF NOT EXISTS idx_chat_attachments_uploader_id ON chat_attachments(uploader_id);
+
 -- Migrations tracking table
 CREATE TABLE IF NOT EXISTS migrations (
     version VARCHAR(255) PRIMARY KEY,
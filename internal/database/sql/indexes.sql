@@ -7,6 +7,10 @@ CREATE INDEX IF NOT EXISTS idx_users_location ON users(latitude, longitude);
 CREATE INDEX IF NOT EXISTS idx_users_age ON users(age);
 CREATE INDEX IF NOT EXISTS idx_users_gender ON users(gender);
 CREATE INDEX IF NOT EXISTS idx_users_active ON users(is_active);
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role) WHERE role <> 'user';
+CREATE INDEX IF NOT EXISTS idx_users_suspended ON users(suspended_at) WHERE suspended_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled ON users(deletion_scheduled_for) WHERE deletion_scheduled_for IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_users_geohash ON users(geohash text_pattern_ops) WHERE is_active = true;
 
 -- Photos table indexes
 CREATE INDEX IF NOT EXISTS idx_photos_user_id ON photos(user_id);
@@ -19,11 +23,13 @@ CREATE INDEX IF NOT EXISTS idx_preferences_user_id ON user_preferences(user_id);
 CREATE INDEX IF NOT EXISTS idx_swipes_user_id ON swipes(user_id);
 CREATE INDEX IF NOT EXISTS idx_swipes_target_id ON swipes(target_id);
 CREATE INDEX IF NOT EXISTS idx_swipes_action ON swipes(action);
+CREATE INDEX IF NOT EXISTS idx_swipes_likes_inbox ON swipes(target_id, created_at DESC, id DESC) WHERE action IN ('right', 'super');
 
 -- Matches table indexes
 CREATE INDEX IF NOT EXISTS idx_matches_user1 ON matches(user1_id);
 CREATE INDEX IF NOT EXISTS idx_matches_user2 ON matches(user2_id);
 CREATE INDEX IF NOT EXISTS idx_matches_active ON matches(is_active);
+CREATE INDEX IF NOT EXISTS idx_matches_expires_at ON matches(expires_at) WHERE is_active = true AND expires_at IS NOT NULL;
 
 -- Chats table indexes
 CREATE INDEX IF NOT EXISTS idx_chats_match_id ON chats(match_id);
@@ -34,6 +40,10 @@ CREATE INDEX IF NOT EXISTS idx_messages_chat_id ON messages(chat_id);
 CREATE INDEX IF NOT EXISTS idx_messages_sender ON messages(sender_id);
 CREATE INDEX IF NOT EXISTS idx_messages_created_at ON messages(created_at);
 CREATE INDEX IF NOT EXISTS idx_messages_unread ON messages(is_read) WHERE is_read = false;
+CREATE INDEX IF NOT EXISTS idx_messages_undelivered ON messages(chat_id) WHERE delivered_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_client_message_id ON messages(sender_id, client_message_id) WHERE client_message_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_chat_seq ON messages(chat_id, seq);
+CREATE INDEX IF NOT EXISTS idx_messages_chat_change_seq ON messages(chat_id, change_seq) WHERE change_seq > 0;
 
 -- Refresh tokens table indexes
 CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
@@ -64,4 +74,31 @@ CREATE INDEX IF NOT EXISTS idx_notification_preferences_user_id ON notification_
 CREATE INDEX IF NOT EXISTS idx_images_user_id ON images(user_id);
 CREATE INDEX IF NOT EXISTS idx_images_position ON images(user_id, position);
 CREATE INDEX IF NOT EXISTS idx_images_s3_key ON images(s3_key);
-CREATE INDEX IF NOT EXISTS idx_images_active ON images(is_active);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_images_active ON images(is_active);
+
+-- Moderation actions table indexes
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_target ON moderation_actions(target_user_id);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_moderator ON moderation_actions(moderator_id);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_created_at ON moderation_actions(created_at);
+
+-- Account deletion receipts table indexes
+CREATE INDEX IF NOT EXISTS idx_account_deletion_receipts_user_id ON account_deletion_receipts(user_id);
+CREATE INDEX IF NOT EXISTS idx_account_deletion_receipts_email_hash ON account_deletion_receipts(email_hash);
+
+-- Data exports table indexes
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_data_exports_status ON data_exports(status) WHERE status IN ('pending', 'processing');
+
+-- Boosts table indexes
+CREATE INDEX IF NOT EXISTS idx_boosts_user_id ON boosts(user_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_boosts_ends_at ON boosts(ends_at);
+
+-- Message edits table indexes
+CREATE INDEX IF NOT EXISTS idx_message_edits_message_id ON message_edits(message_id, edited_at);
+
+-- Message reactions table indexes
+CREATE INDEX IF NOT EXISTS idx_message_reactions_user_id ON message_reactions(user_id);
+
+-- Chat attachments table indexes
+CREATE INDEX IF NOT EXISTS idx_chat_attachments_chat_id ON chat_attachments(chat_id);
+CREATE INDEX IF NOT EXISTS idx_chat_attachments_uploader_id ON chat_attachments(uploader_id);
\ No newline at end of file
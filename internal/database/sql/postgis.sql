@@ -0,0 +1,32 @@
+-- Optional PostGIS setup for Matching API
+-- Run this file after the schema on databases with PostGIS installed. Discovery
+-- radius queries then use a GiST-indexed geography column instead of geohash prefixes.
+
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS geog geography(Point, 4326);
+
+UPDATE users SET geog = ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)::geography
+WHERE latitude IS NOT NULL AND longitude IS NOT NULL;
+
+UPDATE users SET geohash = ST_GeoHash(geog::geometry, 9)
+WHERE geog IS NOT NULL AND geohash IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_geog ON users USING GIST (geog);
+
+-- Keep the geography column in sync with latitude/longitude
+CREATE OR REPLACE FUNCTION users_sync_geog() RETURNS trigger AS $$
+BEGIN
+    IF NEW.latitude IS NULL OR NEW.longitude IS NULL THEN
+        NEW.geog := NULL;
+    ELSE
+        NEW.geog := ST_SetSRID(ST_MakePoint(NEW.longitude, NEW.latitude), 4326)::geography;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_users_sync_geog ON users;
+CREATE TRIGGER trg_users_sync_geog
+    BEFORE INSERT OR UPDATE OF latitude, longitude ON users
+    FOR EACH ROW EXECUTE PROCEDURE users_sync_geog();
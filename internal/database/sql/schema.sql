@@ -18,6 +18,16 @@ CREATE TABLE IF NOT EXISTS users (
     country VARCHAR(100),
     is_active BOOLEAN DEFAULT true,
     last_seen TIMESTAMP WITH TIME ZONE,
+    role VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (role IN ('user', 'moderator', 'admin')),
+    suspended_at TIMESTAMP WITH TIME ZONE,
+    suspended_until TIMESTAMP WITH TIME ZONE,
+    suspension_reason TEXT,
+    deletion_requested_at TIMESTAMP WITH TIME ZONE,
+    deletion_scheduled_for TIMESTAMP WITH TIME ZONE,
+    deletion_reason TEXT,
+    geohash VARCHAR(12),
+    subscription_tier VARCHAR(20) NOT NULL DEFAULT 'free' CHECK (subscription_tier IN ('free', 'plus', 'premium')),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
@@ -44,6 +54,7 @@ CREATE TABLE IF NOT EXISTS user_preferences (
     only_verified BOOLEAN DEFAULT false,
     hide_distance BOOLEAN DEFAULT false,
     hide_age BOOLEAN DEFAULT false,
+    send_read_receipts BOOLEAN NOT NULL DEFAULT true,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     UNIQUE(user_id)
@@ -65,6 +76,13 @@ CREATE TABLE IF NOT EXISTS matches (
     user1_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     user2_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
     is_active BOOLEAN DEFAULT true,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    extended_at TIMESTAMP WITH TIME ZONE,
+    expiry_reminder_sent_at TIMESTAMP WITH TIME ZONE,
+    expired_at TIMESTAMP WITH TIME ZONE,
+    unmatched_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    unmatch_reason TEXT,
+    unmatched_at TIMESTAMP WITH TIME ZONE,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     UNIQUE(user1_id, user2_id),
     CHECK (user1_id != user2_id)
@@ -75,6 +93,9 @@ CREATE TABLE IF NOT EXISTS chats (
     id UUID PRIMARY KEY,
     match_id UUID NOT NULL REFERENCES matches(id) ON DELETE CASCADE,
     last_message_at TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    last_seq BIGINT NOT NULL DEFAULT 0,
+    last_change_seq BIGINT NOT NULL DEFAULT 0,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
     UNIQUE(match_id)
@@ -88,6 +109,14 @@ CREATE TABLE IF NOT EXISTS messages (
     content TEXT NOT NULL,
     message_type VARCHAR(20) DEFAULT 'text' CHECK (message_type IN ('text', 'image', 'gif')),
     is_read BOOLEAN DEFAULT false,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    read_at TIMESTAMP WITH TIME ZONE,
+    client_message_id VARCHAR(64),
+    seq BIGINT NOT NULL,
+    change_seq BIGINT NOT NULL DEFAULT 0,
+    edited_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    gif JSONB,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
 );
 
@@ -170,6 +199,85 @@ CREATE TABLE IF NOT EXISTS images (
     UNIQUE(user_id, position)
 );
 
+-- Moderation actions table
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id UUID PRIMARY KEY,
+    moderator_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    target_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    reason TEXT,
+    metadata JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Account deletion receipts table
+CREATE TABLE IF NOT EXISTS account_deletion_receipts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    email_hash VARCHAR(64) NOT NULL,
+    reason TEXT,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    storage_objects_deleted INTEGER NOT NULL DEFAULT 0,
+    analytics_events_anonymized BIGINT NOT NULL DEFAULT 0
+);
+
+-- Data exports table
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'completed', 'failed')),
+    s3_key VARCHAR(500),
+    size BIGINT,
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Boosts table
+CREATE TABLE IF NOT EXISTS boosts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    impressions INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CHECK (ends_at > started_at)
+);
+
+-- Message edits table
+CREATE TABLE IF NOT EXISTS message_edits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Message reactions table
+CREATE TABLE IF NOT EXISTS message_reactions (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id)
+);
+
+-- Chat attachments table
+CREATE TABLE IF NOT EXISTS chat_attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    chat_id UUID NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+    uploader_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    message_id UUID UNIQUE REFERENCES messages(id) ON DELETE SET NULL,
+    s3_key VARCHAR(500) NOT NULL,
+    thumbnail_key VARCHAR(500) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
 -- Migrations tracking table
 CREATE TABLE IF NOT EXISTS migrations (
     version VARCHAR(255) PRIMARY KEY,
@@ -0,0 +1,25 @@
+package admin
+
+import (
+	"matching-api/internal/handlers/shared"
+	internalServices "matching-api/internal/services"
+	"matching-api/pkg/auth"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/services"
+)
+
+// Handler handles admin and moderation requests
+type Handler struct {
+	shared.BaseHandler
+	Revocations auth.RevocationStore
+	Analytics   *internalServices.AnalyticsService
+}
+
+// NewHandler creates a new admin handler
+func NewHandler(redisService *services.RedisService, cacheStore cache.Cache, revocations auth.RevocationStore) *Handler {
+	return &Handler{
+		BaseHandler: shared.NewBaseHandler(redisService, cacheStore),
+		Revocations: revocations,
+		Analytics:   internalServices.NewAnalyticsService(),
+	}
+}
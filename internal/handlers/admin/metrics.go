@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"matching-api/pkg/utils"
+)
+
+// GetDashboardMetrics returns the admin dashboard metrics
+// @Summary Get dashboard metrics
+// @Description Get overview, engagement, revenue, geographic, demographic and trending metrics (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.DashboardMetrics} "Dashboard metrics retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/metrics/dashboard [get]
+func (h *Handler) GetDashboardMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics, err := h.Analytics.GetDashboardMetrics()
+	if err != nil {
+		utils.LogError("Failed to get dashboard metrics", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Dashboard metrics retrieved successfully", metrics)
+}
+
+// GetFunnelAnalysis returns the conversion funnel
+// @Summary Get funnel analysis
+// @Description Get the user conversion funnel from install to active user (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.FunnelAnalysis} "Funnel analysis retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/metrics/funnel [get]
+func (h *Handler) GetFunnelAnalysis(w http.ResponseWriter, r *http.Request) {
+	funnel, err := h.Analytics.GetFunnelAnalysis()
+	if err != nil {
+		utils.LogError("Failed to get funnel analysis", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Funnel analysis retrieved successfully", funnel)
+}
+
+// GetEventSummary returns event counts for a time period
+// @Summary Get event summary
+// @Description Get event counts and unique users per event type (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Number of days to summarize (max 90)" default(7)
+// @Success 200 {object} models.APIResponse{data=object{events=[]models.EventSummary,days=int}} "Event summary retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/metrics/events [get]
+func (h *Handler) GetEventSummary(w http.ResponseWriter, r *http.Request) {
+	days := 7
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		if d, err := strconv.Atoi(daysStr); err == nil && d > 0 && d <= 90 {
+			days = d
+		}
+	}
+
+	events, err := h.Analytics.GetEventSummary(days)
+	if err != nil {
+		utils.LogError("Failed to get event summary", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Event summary retrieved successfully", map[string]interface{}{
+		"events": events,
+		"days":   days,
+	})
+}
+
+// GetUserMetrics returns engagement metrics for a single user
+// @Summary Get user metrics
+// @Description Get swipe, match and messaging metrics for a user (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID"
+// @Success 200 {object} models.APIResponse{data=models.UserMetrics} "User metrics retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/metrics/users/{userID} [get]
+func (h *Handler) GetUserMetrics(w http.ResponseWriter, r *http.Request) {
+	metrics, err := h.Analytics.GetUserMetrics(chi.URLParam(r, "userID"))
+	if err != nil {
+		utils.LogError("Failed to get user metrics", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "User metrics retrieved successfully", metrics)
+}
@@ -0,0 +1,191 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/utils"
+)
+
+// ModerateImage approves or removes a user's image
+// @Summary Moderate image
+// @Description Approve an image or remove it from the owner's profile (moderator or admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param imageID path string true "Image ID"
+// @Param request body models.ModerateImageRequest true "Moderation decision"
+// @Success 200 {object} models.APIResponse "Image moderated successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} models.ErrorResponse "Image not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/images/{imageID}/moderate [post]
+func (h *Handler) ModerateImage(w http.ResponseWriter, r *http.Request) {
+	moderator, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	var req models.ModerateImageRequest
+	if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	imageID := chi.URLParam(r, "imageID")
+
+	var ownerID, s3Key string
+	err := database.DB.QueryRow(`SELECT user_id, s3_key FROM images WHERE id = $1`, imageID).Scan(&ownerID, &s3Key)
+	if err == sql.ErrNoRows {
+		utils.WriteNotFound(w, "Image not found")
+		return
+	}
+	if err != nil {
+		utils.LogError("Error querying image", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	action := models.ModerationApproveImage
+	if req.Action == "remove" {
+		action = models.ModerationRemoveImage
+
+		if _, err := database.DB.Exec(`UPDATE images SET is_active = false, updated_at = NOW() WHERE id = $1`, imageID); err != nil {
+			utils.LogError("Error removing image", err)
+			utils.WriteInternalError(w, err)
+			return
+		}
+
+		if err := cache.InvalidateUser(h.Cache, ownerID); err != nil {
+			utils.LogError("Failed to invalidate user cache", err)
+		}
+	}
+
+	metadata := map[string]interface{}{"image_id": imageID, "s3_key": s3Key}
+	if err := recordModerationAction(moderator.UserID, ownerID, action, req.Reason, metadata); err != nil {
+		utils.LogError("Failed to record moderation action", err)
+	}
+
+	utils.WriteSuccessResponse(w, "Image moderated successfully", map[string]interface{}{
+		"image_id": imageID,
+		"action":   action,
+	})
+}
+
+// GetModerationActions lists the moderation audit log
+// @Summary List moderation actions
+// @Description List moderation actions, newest first (moderator or admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string false "Only actions taken against this user"
+// @Param limit query int false "Number of actions to return (max 100)" default(50)
+// @Param offset query int false "Number of actions to skip" default(0)
+// @Success 200 {object} models.APIResponse{data=object{actions=[]models.ModerationAction,total=int,limit=int,offset=int}} "Moderation actions retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/moderation/actions [get]
+func (h *Handler) GetModerationActions(w http.ResponseWriter, r *http.Request) {
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	// Get pagination parameters
+	limit := 50
+	offset := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	where := ""
+	args := []any{}
+	argIndex := 1
+
+	if userID := r.URL.Query().Get("user_id"); userID != "" {
+		where = " WHERE target_user_id = $" + strconv.Itoa(argIndex)
+		args = append(args, userID)
+		argIndex++
+	}
+
+	var total int
+	if err := database.DB.QueryRow(`SELECT COUNT(*) FROM moderation_actions`+where, args...).Scan(&total); err != nil {
+		utils.LogError("Error counting moderation actions", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	query := `
+		SELECT id, moderator_id, target_user_id, action, COALESCE(reason, ''), metadata, created_at
+		FROM moderation_actions` + where +
+		" ORDER BY created_at DESC LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		utils.LogError("Error querying moderation actions", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	actions := []models.ModerationAction{}
+	for rows.Next() {
+		var action models.ModerationAction
+		var metadataBytes []byte
+
+		err := rows.Scan(
+			&action.ID, &action.ModeratorID, &action.TargetUserID, &action.Action,
+			&action.Reason, &metadataBytes, &action.CreatedAt,
+		)
+		if err != nil {
+			utils.LogError("Error scanning moderation action row", err)
+			continue
+		}
+
+		if metadataBytes != nil {
+			if err := json.Unmarshal(metadataBytes, &action.Metadata); err != nil {
+				utils.LogError("Error unmarshaling moderation metadata", err)
+			}
+		}
+
+		actions = append(actions, action)
+	}
+
+	utils.WriteSuccessResponse(w, "Moderation actions retrieved successfully", map[string]interface{}{
+		"actions": actions,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
@@ -0,0 +1,373 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// SearchUsers looks up users by email or name
+// @Summary Search users
+// @Description Look up users by email or name (moderator or admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Email or name to search for"
+// @Param role query string false "Filter by role" Enums(user, moderator, admin)
+// @Param suspended query bool false "Only return suspended users"
+// @Param limit query int false "Number of users to return (max 100)" default(20)
+// @Param offset query int false "Number of users to skip" default(0)
+// @Success 200 {object} models.APIResponse{data=object{users=[]models.User,total=int,limit=int,offset=int}} "Users retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users [get]
+func (h *Handler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	// Get pagination parameters
+	limit := 20
+	offset := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	// Build the filters shared by the count and the page
+	where := ` WHERE 1=1`
+	args := []any{}
+	argIndex := 1
+
+	if q := r.URL.Query().Get("q"); q != "" {
+		where += " AND (email ILIKE $" + strconv.Itoa(argIndex) + ` ESCAPE '\'` +
+			" OR (first_name || ' ' || last_name) ILIKE $" + strconv.Itoa(argIndex) + ` ESCAPE '\')`
+		args = append(args, "%"+escapeLike(q)+"%")
+		argIndex++
+	}
+
+	if role := models.Role(r.URL.Query().Get("role")); role != "" {
+		if !role.IsValid() {
+			utils.WriteErrorResponse(w, "Invalid role", http.StatusBadRequest)
+			return
+		}
+		where += " AND role = $" + strconv.Itoa(argIndex)
+		args = append(args, string(role))
+		argIndex++
+	}
+
+	if r.URL.Query().Get("suspended") == "true" {
+		where += " AND suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW())"
+	}
+
+	var total int
+	if err := database.DB.QueryRow(`SELECT COUNT(*) FROM users`+where, args...).Scan(&total); err != nil {
+		utils.LogError("Error counting users", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	query := `SELECT ` + userColumns + ` FROM users` + where +
+		" ORDER BY created_at DESC LIMIT $" + strconv.Itoa(argIndex) + " OFFSET $" + strconv.Itoa(argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		utils.LogError("Error querying users", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	users := []models.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			utils.LogError("Error scanning user row", err)
+			continue
+		}
+		users = append(users, *user)
+	}
+
+	utils.WriteSuccessResponse(w, "Users retrieved successfully", map[string]interface{}{
+		"users":  users,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// GetUser returns the full record of a user
+// @Summary Get user
+// @Description Get a user's account, role and moderation state (moderator or admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID"
+// @Success 200 {object} models.APIResponse{data=models.User} "User retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users/{userID} [get]
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	user, err := getUserByID(chi.URLParam(r, "userID"))
+	if err != nil {
+		utils.LogError("Error querying user", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if user == nil {
+		utils.WriteNotFound(w, "User not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "User retrieved successfully", user)
+}
+
+// SuspendUser suspends a user and revokes their access immediately
+// @Summary Suspend user
+// @Description Suspend a user for a number of hours, or indefinitely when no duration is given. All of the user's access tokens stop working immediately. (moderator or admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID"
+// @Param request body models.SuspendUserRequest true "Suspension details"
+// @Success 200 {object} models.APIResponse{data=models.User} "User suspended successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users/{userID}/suspend [post]
+func (h *Handler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	moderator, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	var req models.SuspendUserRequest
+	if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	target, ok := h.loadManageableUser(w, moderator, chi.URLParam(r, "userID"))
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	var suspendedUntil *time.Time
+	if req.DurationHours > 0 {
+		until := now.Add(time.Duration(req.DurationHours) * time.Hour)
+		suspendedUntil = &until
+	}
+
+	_, err := database.DB.Exec(`
+		UPDATE users
+		SET suspended_at = $1, suspended_until = $2, suspension_reason = $3, updated_at = NOW()
+		WHERE id = $4
+	`, now, suspendedUntil, req.Reason, target.ID)
+	if err != nil {
+		utils.LogError("Error suspending user", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	h.revokeUserAccess(target.ID)
+
+	metadata := map[string]interface{}{"duration_hours": req.DurationHours}
+	if err := recordModerationAction(moderator.UserID, target.ID, models.ModerationSuspendUser, req.Reason, metadata); err != nil {
+		utils.LogError("Failed to record moderation action", err)
+	}
+
+	target.SuspendedAt = &now
+	target.SuspendedUntil = suspendedUntil
+	target.SuspensionReason = req.Reason
+
+	utils.WriteSuccessResponse(w, "User suspended successfully", target)
+}
+
+// UnsuspendUser lifts a user's suspension
+// @Summary Unsuspend user
+// @Description Lift a user's suspension (moderator or admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID"
+// @Success 200 {object} models.APIResponse{data=models.User} "User unsuspended successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users/{userID}/unsuspend [post]
+func (h *Handler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	moderator, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	target, ok := h.loadManageableUser(w, moderator, chi.URLParam(r, "userID"))
+	if !ok {
+		return
+	}
+
+	_, err := database.DB.Exec(`
+		UPDATE users
+		SET suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, target.ID)
+	if err != nil {
+		utils.LogError("Error unsuspending user", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if err := recordModerationAction(moderator.UserID, target.ID, models.ModerationUnsuspendUser, "", nil); err != nil {
+		utils.LogError("Failed to record moderation action", err)
+	}
+
+	target.SuspendedAt = nil
+	target.SuspendedUntil = nil
+	target.SuspensionReason = ""
+
+	utils.WriteSuccessResponse(w, "User unsuspended successfully", target)
+}
+
+// UpdateUserRole changes a user's role
+// @Summary Update user role
+// @Description Change a user's role. The user's existing access tokens are revoked so the new role applies on their next refresh. (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID"
+// @Param request body models.UpdateRoleRequest true "New role"
+// @Success 200 {object} models.APIResponse{data=models.User} "User role updated successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Insufficient permissions"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /admin/users/{userID}/role [put]
+func (h *Handler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
+	admin, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	var req models.UpdateRoleRequest
+	if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	userID := chi.URLParam(r, "userID")
+	if userID == admin.UserID {
+		utils.WriteForbidden(w, "You cannot change your own role")
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	target, err := getUserByID(userID)
+	if err != nil {
+		utils.LogError("Error querying user", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if target == nil {
+		utils.WriteNotFound(w, "User not found")
+		return
+	}
+
+	previousRole := target.Role
+	if _, err := database.DB.Exec(`UPDATE users SET role = $1, updated_at = NOW() WHERE id = $2`, string(req.Role), target.ID); err != nil {
+		utils.LogError("Error updating user role", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	h.revokeUserAccess(target.ID)
+
+	metadata := map[string]interface{}{"from": previousRole, "to": req.Role}
+	if err := recordModerationAction(admin.UserID, target.ID, models.ModerationChangeRole, "", metadata); err != nil {
+		utils.LogError("Failed to record moderation action", err)
+	}
+
+	target.Role = req.Role
+	utils.WriteSuccessResponse(w, "User role updated successfully", target)
+}
+
+// loadManageableUser loads the target user and checks the acting user may
+// moderate them. Staff cannot act on themselves, and moderators cannot act on
+// other staff. It writes the error response and returns false on failure.
+func (h *Handler) loadManageableUser(w http.ResponseWriter, actor *models.JWTClaims, userID string) (*models.User, bool) {
+	if userID == actor.UserID {
+		utils.WriteForbidden(w, "You cannot moderate your own account")
+		return nil, false
+	}
+
+	target, err := getUserByID(userID)
+	if err != nil {
+		utils.LogError("Error querying user", err)
+		utils.WriteInternalError(w, err)
+		return nil, false
+	}
+	if target == nil {
+		utils.WriteNotFound(w, "User not found")
+		return nil, false
+	}
+
+	if target.Role != models.RoleUser && !actor.Role.HasAtLeast(models.RoleAdmin) {
+		utils.WriteForbidden(w, "Only admins can moderate staff accounts")
+		return nil, false
+	}
+
+	return target, true
+}
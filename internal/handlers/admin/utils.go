@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/utils"
+)
+
+// userColumns lists the user columns returned by admin lookups
+const userColumns = `
+	id, email, first_name, last_name, age, COALESCE(bio, ''), gender,
+	COALESCE(is_active, true), role, last_seen,
+	suspended_at, suspended_until, COALESCE(suspension_reason, ''),
+	created_at, updated_at`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanUser scans a row selected with userColumns
+func scanUser(row rowScanner) (*models.User, error) {
+	var user models.User
+	err := row.Scan(
+		&user.ID, &user.Email, &user.FirstName, &user.LastName, &user.Age,
+		&user.Bio, &user.Gender, &user.IsActive, &user.Role, &user.LastSeen,
+		&user.SuspendedAt, &user.SuspendedUntil, &user.SuspensionReason,
+		&user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// getUserByID loads a user for admin views, returning nil if not found
+func getUserByID(userID string) (*models.User, error) {
+	row := database.DB.QueryRow(`SELECT `+userColumns+` FROM users WHERE id = $1`, userID)
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
+// recordModerationAction writes an entry to the moderation audit log
+func recordModerationAction(moderatorID, targetUserID string, action models.ModerationActionType, reason string, metadata map[string]interface{}) error {
+	var metadataJSON []byte
+	if metadata != nil {
+		var err error
+		if metadataJSON, err = json.Marshal(metadata); err != nil {
+			return err
+		}
+	}
+
+	_, err := database.DB.Exec(`
+		INSERT INTO moderation_actions (id, moderator_id, target_user_id, action, reason, metadata, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+	`, uuid.New().String(), moderatorID, targetUserID, string(action), reason, metadataJSON, time.Now())
+	return err
+}
+
+// revokeUserAccess invalidates every access token the user holds and clears
+// cached data so a suspension or role change takes effect immediately
+func (h *Handler) revokeUserAccess(userID string) {
+	if h.Revocations != nil {
+		if err := h.Revocations.RevokeUserTokens(userID, time.Now()); err != nil {
+			utils.LogError("Failed to revoke user access tokens", err)
+		}
+	}
+
+	if err := cache.InvalidateUser(h.Cache, userID); err != nil {
+		utils.LogError("Failed to invalidate user cache", err)
+	}
+}
+
+// likeEscaper escapes the LIKE wildcards in user input, for patterns using
+// ESCAPE '\'
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLike makes a search term match literally inside a LIKE pattern
+func escapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
@@ -1,18 +1,37 @@
 package auth
 
 import (
+	"golang.org/x/crypto/bcrypt"
+
 	"matching-api/internal/handlers/shared"
+	internalServices "matching-api/internal/services"
+	"matching-api/pkg/auth"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/services"
 )
 
 // Handler handles authentication-related requests
 type Handler struct {
 	shared.BaseHandler
+	Revocations auth.RevocationStore
+	LoginGuard  *auth.LoginGuard
+	Analytics   *internalServices.AnalyticsService
+
+	// dummyHash is compared against when no user matches the email, so login
+	// timing does not reveal whether an account exists. It is computed up
+	// front so the first unknown email isn't slower than the rest.
+	dummyHash []byte
 }
 
 // NewHandler creates a new auth handler
-func NewHandler(redisService *services.RedisService) *Handler {
+func NewHandler(redisService *services.RedisService, cacheStore cache.Cache, revocations auth.RevocationStore) *Handler {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("dummy-password-for-timing"), bcrypt.DefaultCost)
+
 	return &Handler{
-		BaseHandler: shared.NewBaseHandler(redisService),
+		BaseHandler: shared.NewBaseHandler(redisService, cacheStore),
+		Revocations: revocations,
+		LoginGuard:  auth.NewLoginGuard(redisService),
+		Analytics:   internalServices.NewAnalyticsService(),
+		dummyHash:   dummyHash,
 	}
 }
\ No newline at end of file
@@ -21,6 +21,8 @@ import (
 // @Success 200 {object} models.APIResponse{data=models.AuthResponse} "Login successful"
 // @Failure 401 {object} models.ErrorResponse "Invalid credentials"
 // @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
+// @Failure 403 {object} models.ErrorResponse "Account suspended"
+// @Failure 429 {object} models.ErrorResponse "Too many failed attempts or account temporarily locked"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /auth/login [post]
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
@@ -30,21 +32,48 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Throttle per account, regardless of which IP the attempts come from
+	attempt, err := h.LoginGuard.Begin(req.Email)
+	if err != nil {
+		utils.LogError("Failed to check login attempts", err)
+	} else if attempt.Locked {
+		utils.WriteTooManyRequests(w, "Account temporarily locked due to too many failed login attempts", attempt.RetryAfter)
+		return
+	} else if attempt.RetryAfter > 0 {
+		utils.WriteTooManyRequests(w, "Too many failed login attempts, please wait before retrying", attempt.RetryAfter)
+		return
+	}
+
 	// Find user by email from database
 	user := h.getUserByEmail(req.Email)
-	if user == nil {
+
+	// Always run bcrypt so unknown emails take as long as wrong passwords
+	passwordHash := h.dummyHash
+	if user != nil {
+		passwordHash = []byte(user.Password)
+	}
+	passwordErr := bcrypt.CompareHashAndPassword(passwordHash, []byte(req.Password))
+
+	if user == nil || passwordErr != nil {
+		h.recordFailedLogin(req.Email, attempt, r)
 		utils.WriteUnauthorized(w, "Invalid email or password")
 		return
 	}
 
-	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		utils.WriteUnauthorized(w, "Invalid email or password")
+	if err := h.LoginGuard.RecordSuccess(req.Email); err != nil {
+		utils.LogError("Failed to reset login attempts", err)
+	}
+
+	// Suspended accounts cannot obtain new tokens
+	h.loadAccountStatus(user)
+	if user.IsSuspended() {
+		utils.WriteForbidden(w, "Account suspended")
 		return
 	}
 
-	// Generate tokens
-	accessToken, refreshToken, err := h.generateTokenPair(user.ID, user.Email)
+	// Generate tokens for a new device session
+	session := newSession(user.ID, req.DeviceName, req.Platform, r)
+	accessToken, refreshToken, err := h.generateTokenPair(user, session.ID)
 	if err != nil {
 		utils.WriteInternalError(w, err)
 		return
@@ -53,17 +82,29 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	// Update last seen and store session
 	user.LastSeen = &[]time.Time{time.Now()}[0]
 	
-	if err := h.storeUserSession(user, refreshToken); err != nil {
+	if err := h.storeUserSession(user, refreshToken, session); err != nil {
 		utils.LogError("Failed to store user session during login", err)
 	}
 
 	// Prepare response
 	authResponse := models.AuthResponse{
-		User:         user.Public(),
+		User:         user.Own(),
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresIn:    int64(auth.AccessTokenExpiry.Seconds()),
 	}
 
 	utils.WriteSuccessResponse(w, "Login successful", authResponse)
-}
\ No newline at end of file
+}
+
+// recordFailedLogin reports a lockout when the failed attempt started one. The
+// failure itself was already counted when the attempt began.
+func (h *Handler) recordFailedLogin(email string, attempt *auth.LoginAttempt, r *http.Request) {
+	if attempt == nil || !attempt.LockStarted {
+		return
+	}
+
+	if err := h.Analytics.TrackAccountLocked(auth.LoginKey(email), attempt.Failures, auth.LoginLockoutDuration, r); err != nil {
+		utils.LogError("Failed to track account lockout", err)
+	}
+}
@@ -50,28 +50,30 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 		Age:       req.Age,
 		Gender:    req.Gender,
 		IsActive:  true,
+		Role:      models.RoleUser,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	// TODO: Save user to database here
 	
-	// Generate tokens
-	accessToken, refreshToken, err := h.generateTokenPair(user.ID, user.Email)
+	// Generate tokens for a new device session
+	session := newSession(user.ID, req.DeviceName, req.Platform, r)
+	accessToken, refreshToken, err := h.generateTokenPair(user, session.ID)
 	if err != nil {
 		utils.WriteInternalError(w, err)
 		return
 	}
 
 	// Store session and cache user data
-	if err := h.storeUserSession(user, refreshToken); err != nil {
+	if err := h.storeUserSession(user, refreshToken, session); err != nil {
 		// Log error but don't fail the request
 		utils.LogError("Failed to store user session during registration", err)
 	}
 	
 	// Prepare response
 	authResponse := models.AuthResponse{
-		User:         user.Public(),
+		User:         user.Own(),
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 		ExpiresIn:    int64(auth.AccessTokenExpiry.Seconds()),
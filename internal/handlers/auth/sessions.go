@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"matching-api/internal/middleware"
+	"matching-api/pkg/utils"
+)
+
+// GetSessions lists the active sessions (logged-in devices) of the current user
+// @Summary List active sessions
+// @Description List all devices the current user is logged in on
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=object{sessions=[]models.Session,total=int}} "Sessions retrieved successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 503 {object} models.ErrorResponse "Session store not available"
+// @Router /auth/sessions [get]
+func (h *Handler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if h.RedisService == nil {
+		utils.WriteErrorResponse(w, "Session store not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	sessions, err := h.getUserSessions(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to list user sessions", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	// Flag the session the request was made from
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == userClaims.SessionID
+	}
+
+	utils.WriteSuccessResponse(w, "Sessions retrieved successfully", map[string]interface{}{
+		"sessions": sessions,
+		"total":    len(sessions),
+	})
+}
+
+// RevokeSession logs out a single device of the current user
+// @Summary Revoke a session
+// @Description Log out a specific device by revoking its session and refresh token
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sessionID path string true "Session ID"
+// @Success 200 {object} models.APIResponse "Session revoked successfully"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Session not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 503 {object} models.ErrorResponse "Session store not available"
+// @Router /auth/sessions/{sessionID} [delete]
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	sessionID := chi.URLParam(r, "sessionID")
+	if sessionID == "" {
+		utils.WriteErrorResponse(w, "Session ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if h.RedisService == nil {
+		utils.WriteErrorResponse(w, "Session store not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	revoked, err := h.revokeUserSession(userClaims.UserID, sessionID)
+	if err != nil {
+		utils.LogError("Failed to revoke session", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if !revoked {
+		utils.WriteNotFound(w, "Session not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Session revoked successfully", nil)
+}
+
+// LogoutAll logs the current user out of every device
+// @Summary Log out everywhere
+// @Description Revoke every session of the current user, including the current one. All access tokens issued before the request stop working immediately.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=object{sessions_revoked=int}} "Logged out of all sessions"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /auth/logout-all [post]
+func (h *Handler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	// Get user from context
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	// Invalidate every access token issued so far, including ones without a session
+	if h.Revocations != nil {
+		if err := h.Revocations.RevokeUserTokens(userClaims.UserID, time.Now()); err != nil {
+			utils.LogError("Failed to revoke user access tokens", err)
+			utils.WriteInternalError(w, err)
+			return
+		}
+	}
+
+	revoked := 0
+	if h.RedisService != nil {
+		var err error
+		revoked, err = h.revokeAllUserSessions(userClaims.UserID)
+		if err != nil {
+			utils.LogError("Failed to revoke all sessions", err)
+			utils.WriteInternalError(w, err)
+			return
+		}
+	}
+
+	utils.WriteSuccessResponse(w, "Logged out of all sessions", map[string]interface{}{
+		"sessions_revoked": revoked,
+	})
+}
@@ -18,6 +18,7 @@ import (
 // @Param request body models.RefreshTokenRequest true "Refresh token"
 // @Success 200 {object} models.APIResponse{data=models.AuthResponse} "Token refreshed successfully"
 // @Failure 401 {object} models.ErrorResponse "Invalid refresh token"
+// @Failure 403 {object} models.ErrorResponse "Account suspended"
 // @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /auth/refresh [post]
@@ -29,27 +30,39 @@ func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Verify refresh token and get user
-	user := h.verifyRefreshToken(req.RefreshToken)
+	user, session := h.verifyRefreshToken(req.RefreshToken)
 	if user == nil {
 		utils.WriteUnauthorized(w, "Invalid refresh token")
 		return
 	}
 
+	// Pick up role changes and reject suspended accounts
+	h.loadAccountStatus(user)
+	if user.IsSuspended() {
+		utils.WriteForbidden(w, "Account suspended")
+		return
+	}
+
+	// Sessions are only tracked when Redis is available
+	if session == nil {
+		session = newSession(user.ID, "", "", r)
+	}
+
 	// Generate new tokens
-	accessToken, newRefreshToken, err := h.generateTokenPair(user.ID, user.Email)
+	accessToken, newRefreshToken, err := h.generateTokenPair(user, session.ID)
 	if err != nil {
 		utils.WriteInternalError(w, err)
 		return
 	}
 
 	// Update session with new refresh token
-	if err := h.updateUserSession(req.RefreshToken, user, newRefreshToken); err != nil {
+	if err := h.updateUserSession(req.RefreshToken, user, newRefreshToken, session); err != nil {
 		utils.LogError("Failed to update user session during token refresh", err)
 	}
 
 	// Prepare response
 	authResponse := models.AuthResponse{
-		User:         user.Public(),
+		User:         user.Own(),
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken,
 		ExpiresIn:    int64(auth.AccessTokenExpiry.Seconds()),
@@ -60,7 +73,7 @@ func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 
 // Logout handles user logout by invalidating the refresh token
 // @Summary User logout
-// @Description Logout user by invalidating refresh token and clearing session
+// @Description Logout user by invalidating refresh token and clearing session. If an access token is sent in the Authorization header it is revoked immediately.
 // @Tags Authentication
 // @Accept json
 // @Produce json
@@ -76,8 +89,22 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Delete session from Redis
+	// Revoke the presented access token so it cannot be used until it expires
+	if token := auth.ExtractTokenFromHeader(r.Header.Get("Authorization")); token != "" {
+		if claims, err := newJWTService().ValidateAccessToken(token); err == nil {
+			h.revokeAccessToken(claims)
+		}
+	}
+
+	// Delete session from Redis and drop it from the user's session index
 	if h.RedisService != nil {
+		var session models.Session
+		if err := h.RedisService.GetSession(req.RefreshToken, &session); err == nil && session.UserID != "" {
+			if err := h.RedisService.RemoveUserSession(session.UserID, session.ID); err != nil {
+				utils.LogError("Failed to remove session from index during logout", err)
+			}
+			h.revokeSessionTokens(session.ID)
+		}
 		if err := h.RedisService.DeleteSession(req.RefreshToken); err != nil {
 			utils.LogError("Failed to delete session during logout", err)
 		}
@@ -86,16 +113,11 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 	utils.WriteSuccessResponse(w, "Logout successful", nil)
 }
 
-// generateTokenPair generates both access and refresh tokens
-func (h *Handler) generateTokenPair(userID, email string) (string, string, error) {
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "your-super-secret-key-change-this-in-production"
-	}
-	
-	jwtService := auth.NewJWTService(jwtSecret)
-	
-	accessToken, err := jwtService.GenerateAccessToken(userID, email)
+// generateTokenPair generates both access and refresh tokens for a session
+func (h *Handler) generateTokenPair(user *models.User, sessionID string) (string, string, error) {
+	jwtService := newJWTService()
+
+	accessToken, err := jwtService.GenerateAccessToken(user.ID, user.Email, user.Role, sessionID)
 	if err != nil {
 		return "", "", err
 	}
@@ -106,4 +128,14 @@ func (h *Handler) generateTokenPair(userID, email string) (string, string, error
 	}
 
 	return accessToken, refreshToken, nil
-}
\ No newline at end of file
+}
+
+// newJWTService creates a JWT service using the configured secret
+func newJWTService() *auth.JWTService {
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "your-super-secret-key-change-this-in-production"
+	}
+
+	return auth.NewJWTService(jwtSecret)
+}
@@ -1,12 +1,19 @@
 package auth
 
 import (
+	"database/sql"
+	"net"
+	"net/http"
+	"sort"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/google/uuid"
+	"matching-api/internal/database"
 	"matching-api/internal/models"
 	"matching-api/pkg/auth"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/utils"
 )
 
@@ -33,22 +40,47 @@ func (h *Handler) getUserByEmail(email string) *models.User {
 	}
 }
 
-// verifyRefreshToken verifies refresh token and returns user if valid
-func (h *Handler) verifyRefreshToken(token string) *models.User {
+// loadAccountStatus refreshes the user's role and suspension state from the database.
+// Users keep the regular role when the database is not available.
+func (h *Handler) loadAccountStatus(user *models.User) {
+	if user.Role == "" {
+		user.Role = models.RoleUser
+	}
+	if database.DB == nil {
+		return
+	}
+
+	var suspensionReason sql.NullString
+	err := database.DB.QueryRow(`
+		SELECT role, suspended_at, suspended_until, suspension_reason
+		FROM users WHERE id = $1
+	`, user.ID).Scan(&user.Role, &user.SuspendedAt, &user.SuspendedUntil, &suspensionReason)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			utils.LogError("Failed to load account status", err)
+		}
+		return
+	}
+	user.SuspensionReason = suspensionReason.String
+}
+
+// verifyRefreshToken verifies refresh token and returns user and session if valid
+func (h *Handler) verifyRefreshToken(token string) (*models.User, *models.Session) {
 	var user *models.User
+	var session *models.Session
 	
 	if h.RedisService != nil {
-		var sessionData map[string]interface{}
-		if err := h.RedisService.GetSession(token, &sessionData); err == nil {
+		var storedSession models.Session
+		if err := h.RedisService.GetSession(token, &storedSession); err == nil {
 			// Session found in Redis, validate it
-			userID, ok := sessionData["user_id"].(string)
-			if !ok {
-				return nil
+			if storedSession.UserID == "" {
+				return nil, nil
 			}
+			session = &storedSession
 			
 			// Try to get user from cache first
 			var cachedUser models.User
-			if err := h.RedisService.GetCachedUser(userID, &cachedUser); err == nil {
+			if err := h.Cache.Get(cache.UserKey(storedSession.UserID), &cachedUser); err == nil {
 				user = &cachedUser
 			} else {
 				// Fallback to database query
@@ -60,7 +92,7 @@ func (h *Handler) verifyRefreshToken(token string) *models.User {
 		user = h.validateRefreshToken(token)
 	}
 	
-	return user
+	return user, session
 }
 
 // validateRefreshToken validates refresh token against database
@@ -83,27 +115,51 @@ func (h *Handler) validateRefreshToken(token string) *models.User {
 	}
 }
 
-// storeUserSession stores user session in Redis and caches user data
-func (h *Handler) storeUserSession(user *models.User, refreshToken string) error {
+// newSession builds session metadata for the device making the request
+func newSession(userID, deviceName, platform string, r *http.Request) *models.Session {
+	if deviceName == "" {
+		deviceName = "Unknown device"
+	}
+
+	now := time.Now()
+	return &models.Session{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		DeviceName: deviceName,
+		Platform:   platform,
+		IPAddress:  clientIP(r),
+		UserAgent:  r.UserAgent(),
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+}
+
+// clientIP returns the request's remote IP (RealIP middleware resolves proxies)
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+// storeUserSession stores user session in Redis, indexes it per user and caches user data
+func (h *Handler) storeUserSession(user *models.User, refreshToken string, session *models.Session) error {
 	if h.RedisService == nil {
 		return nil // No Redis service available
 	}
 
-	// Store session data
-	sessionData := map[string]interface{}{
-		"user_id":       user.ID,
-		"email":         user.Email,
-		"refresh_token": refreshToken,
-		"created_at":    time.Now(),
-	}
-	
-	if err := h.RedisService.StoreSession(refreshToken, sessionData, auth.RefreshTokenExpiry); err != nil {
+	if err := h.RedisService.StoreSession(refreshToken, session, auth.RefreshTokenExpiry); err != nil {
 		utils.LogError("Failed to store session in Redis", err)
 		return err
 	}
+
+	if err := h.RedisService.AddUserSession(user.ID, session.ID, refreshToken, auth.RefreshTokenExpiry); err != nil {
+		utils.LogError("Failed to index user session", err)
+		return err
+	}
 	
-	// Cache user data for faster profile lookups
-	if err := h.RedisService.CacheUser(user.ID, user.Public(), 30*time.Minute); err != nil {
+	// Cache the user's own profile for faster lookups (the password is never serialized)
+	if err := h.Cache.Set(cache.UserKey(user.ID), user, 30*time.Minute); err != nil {
 		utils.LogError("Failed to cache user data", err)
 		return err
 	}
@@ -111,8 +167,8 @@ func (h *Handler) storeUserSession(user *models.User, refreshToken string) error
 	return nil
 }
 
-// updateUserSession updates existing session with new refresh token
-func (h *Handler) updateUserSession(oldToken string, user *models.User, newToken string) error {
+// updateUserSession rotates the refresh token of an existing session
+func (h *Handler) updateUserSession(oldToken string, user *models.User, newToken string, session *models.Session) error {
 	if h.RedisService == nil {
 		return nil // No Redis service available
 	}
@@ -122,24 +178,119 @@ func (h *Handler) updateUserSession(oldToken string, user *models.User, newToken
 		utils.LogError("Failed to delete old session", err)
 	}
 	
-	// Store new session
-	sessionData := map[string]interface{}{
-		"user_id":       user.ID,
-		"email":         user.Email,
-		"refresh_token": newToken,
-		"refreshed_at":  time.Now(),
-	}
-	
-	if err := h.RedisService.StoreSession(newToken, sessionData, auth.RefreshTokenExpiry); err != nil {
+	// Store the same session under the new refresh token
+	session.LastUsedAt = time.Now()
+	if err := h.RedisService.StoreSession(newToken, session, auth.RefreshTokenExpiry); err != nil {
 		utils.LogError("Failed to store new session", err)
 		return err
 	}
+
+	if err := h.RedisService.AddUserSession(user.ID, session.ID, newToken, auth.RefreshTokenExpiry); err != nil {
+		utils.LogError("Failed to update user session index", err)
+		return err
+	}
 	
 	// Update cached user data
-	if err := h.RedisService.CacheUser(user.ID, user.Public(), 30*time.Minute); err != nil {
+	if err := h.Cache.Set(cache.UserKey(user.ID), user, 30*time.Minute); err != nil {
 		utils.LogError("Failed to update cached user data", err)
 		return err
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// getUserSessions loads all live sessions for a user, pruning index entries
+// whose session data has already expired
+func (h *Handler) getUserSessions(userID string) ([]models.Session, error) {
+	index, err := h.RedisService.GetUserSessions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]models.Session, 0, len(index))
+	for sessionID, refreshToken := range index {
+		var session models.Session
+		if err := h.RedisService.GetSession(refreshToken, &session); err != nil {
+			if err := h.RedisService.RemoveUserSession(userID, sessionID); err != nil {
+				utils.LogError("Failed to prune expired session", err)
+			}
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+// revokeUserSession deletes a session and its refresh token.
+// It returns false if the session does not belong to the user.
+func (h *Handler) revokeUserSession(userID, sessionID string) (bool, error) {
+	index, err := h.RedisService.GetUserSessions(userID)
+	if err != nil {
+		return false, err
+	}
+
+	refreshToken, ok := index[sessionID]
+	if !ok {
+		return false, nil
+	}
+
+	if err := h.RedisService.DeleteSession(refreshToken); err != nil {
+		return false, err
+	}
+	if err := h.RedisService.RemoveUserSession(userID, sessionID); err != nil {
+		return false, err
+	}
+
+	// Access tokens already issued for the session stay valid until they expire
+	// unless the session ID is denylisted as well
+	h.revokeSessionTokens(sessionID)
+
+	return true, nil
+}
+
+// revokeSessionTokens denylists every access token bound to a session for as
+// long as such a token could still be valid
+func (h *Handler) revokeSessionTokens(sessionID string) {
+	if h.Revocations == nil || sessionID == "" {
+		return
+	}
+	if err := h.Revocations.Revoke(sessionID, time.Now().Add(auth.AccessTokenExpiry)); err != nil {
+		utils.LogError("Failed to revoke session access tokens", err)
+	}
+}
+
+// revokeAccessToken denylists a single access token until it expires
+func (h *Handler) revokeAccessToken(claims *models.JWTClaims) {
+	if h.Revocations == nil || claims.TokenID == "" {
+		return
+	}
+	if err := h.Revocations.Revoke(claims.TokenID, time.Unix(claims.Exp, 0)); err != nil {
+		utils.LogError("Failed to revoke access token", err)
+	}
+}
+
+// revokeAllUserSessions deletes every session for a user and returns how many were revoked
+func (h *Handler) revokeAllUserSessions(userID string) (int, error) {
+	index, err := h.RedisService.GetUserSessions(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for sessionID := range index {
+		ok, err := h.revokeUserSession(userID, sessionID)
+		if err != nil {
+			return revoked, err
+		}
+		if ok {
+			revoked++
+		}
+	}
+
+	return revoked, nil
+}
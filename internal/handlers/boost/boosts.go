@@ -0,0 +1,103 @@
+package boost
+
+import (
+	"net/http"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// ActivateBoost starts a profile boost for the current user
+// @Summary Activate boost
+// @Description Start a boost that ranks the current user higher in nearby users' discovery decks for a fixed window. Only one boost can run at a time.
+// @Tags Boosts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} models.APIResponse{data=models.Boost} "Boost activated"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 409 {object} models.APIResponse{data=models.Boost} "A boost is already active"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /boosts [post]
+func (h *Handler) ActivateBoost(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if database.DB == nil || h.Boosts == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	boost, created, err := h.Boosts.Activate(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to activate boost", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if !created {
+		utils.WriteJSONResponse(w, http.StatusConflict, models.APIResponse{
+			Success: false,
+			Error:   "A boost is already active",
+			Data:    boost,
+		})
+		return
+	}
+
+	if err := h.Analytics.TrackEvent(&userClaims.UserID, models.EventBoostPurchased, map[string]interface{}{
+		"boost_id": boost.ID,
+		"ends_at":  boost.EndsAt,
+	}, r); err != nil {
+		utils.LogError("Failed to track boost", err)
+	}
+
+	utils.WriteCreated(w, "Boost activated", boost)
+}
+
+// GetActiveBoost returns the current user's running boost and the results of the last one
+// @Summary Get active boost
+// @Description Get the current user's running boost with its stats so far, and the most recent finished boost with its final stats. Stats compare impressions, counted as swipes received, and likes with what the user got in a window of the same length during the week before the boost.
+// @Tags Boosts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.BoostStatus} "Boost status retrieved"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /boosts/active [get]
+func (h *Handler) GetActiveBoost(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if database.DB == nil || h.Boosts == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	active, err := h.Boosts.GetActive(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to get active boost", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	last, err := h.Boosts.GetLastFinished(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to get last boost", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Boost status retrieved", models.BoostStatus{
+		Active:    active,
+		LastBoost: last,
+	})
+}
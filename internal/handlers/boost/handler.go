@@ -0,0 +1,24 @@
+package boost
+
+import (
+	"matching-api/internal/handlers/shared"
+	internalServices "matching-api/internal/services"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/services"
+)
+
+// Handler handles profile boost requests
+type Handler struct {
+	shared.BaseHandler
+	Boosts    *internalServices.BoostService
+	Analytics *internalServices.AnalyticsService
+}
+
+// NewHandler creates a new boost handler
+func NewHandler(redisService *services.RedisService, cacheStore cache.Cache, boosts *internalServices.BoostService) *Handler {
+	return &Handler{
+		BaseHandler: shared.NewBaseHandler(redisService, cacheStore),
+		Boosts:      boosts,
+		Analytics:   internalServices.NewAnalyticsService(),
+	}
+}
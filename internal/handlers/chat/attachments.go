@@ -0,0 +1,361 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/media"
+	"matching-api/pkg/services"
+	"matching-api/pkg/utils"
+)
+
+const (
+	// maxAttachmentSize is the largest image that can be sent in a chat
+	maxAttachmentSize = 10 << 20
+	// attachmentURLTTL is how long signed attachment URLs work
+	attachmentURLTTL = time.Hour
+	// attachmentThumbnailSize is the longer side of attachment thumbnails
+	attachmentThumbnailSize = 320
+)
+
+// errAttachmentUnavailable is returned when a message names an attachment the
+// sender did not upload to the chat or already sent
+var errAttachmentUnavailable = errors.New("attachment not found or already sent")
+
+// UploadAttachment uploads an image to send in a chat
+// @Summary Upload chat attachment
+// @Description Upload a JPEG, PNG or GIF image to a chat, then send it by passing the returned id as attachment_id to POST /chats/{chatID}/messages or a send_message WebSocket frame. Attachments are private to the two match participants: url and thumbnail_url are signed and expire after an hour, and GET /chats/{chatID}/attachments/{attachmentID} returns fresh ones.
+// @Tags Chat
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param file formData file true "Image file (JPEG/PNG/GIF, max 10MB)"
+// @Success 201 {object} models.APIResponse{data=models.ChatAttachment} "Attachment uploaded"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid file"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Chat not found or access denied"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/attachments [post]
+func (h *Handler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatID")
+	if _, err := uuid.Parse(chatID); err != nil {
+		utils.WriteErrorResponse(w, "Invalid chat ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.S3Service == nil {
+		utils.WriteErrorResponse(w, "S3 service not configured", http.StatusInternalServerError)
+		return
+	}
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	// Check access before reading the upload
+	var isMember bool
+	err := database.DB.QueryRow(`
+		SELECT EXISTS(
+			SELECT 1 FROM chats c
+			JOIN matches m ON c.match_id = m.id
+			WHERE c.id = $1 AND (m.user1_id = $2 OR m.user2_id = $2) AND m.is_active = true AND c.is_active = true
+		)
+	`, chatID, user.UserID).Scan(&isMember)
+	if err != nil {
+		utils.LogError("Error checking chat access", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if !isMember {
+		utils.WriteErrorResponse(w, "Chat not found or access denied", http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAttachmentSize+1<<20)
+	if err := r.ParseMultipartForm(maxAttachmentSize); err != nil {
+		utils.WriteErrorResponse(w, "Failed to parse multipart form", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.WriteErrorResponse(w, "Image file is required", http.StatusBadRequest)
+		return
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			utils.LogError("Error closing file", err)
+		}
+	}()
+	if header.Size > maxAttachmentSize {
+		utils.WriteErrorResponse(w, "Image must be at most 10MB", http.StatusBadRequest)
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.WriteErrorResponse(w, "Failed to read image", http.StatusBadRequest)
+		return
+	}
+
+	// The declared content type is not trusted, the format is read from the data
+	info, err := media.Inspect(data)
+	if err != nil {
+		utils.WriteErrorResponse(w, "Invalid image. Only JPEG, PNG and GIF are allowed", http.StatusBadRequest)
+		return
+	}
+	thumbnail, err := media.Thumbnail(data, attachmentThumbnailSize)
+	if err != nil {
+		utils.LogError("Error creating attachment thumbnail", err)
+		utils.WriteErrorResponse(w, "Invalid image. Only JPEG, PNG and GIF are allowed", http.StatusBadRequest)
+		return
+	}
+
+	attachment := models.ChatAttachment{
+		ID:          uuid.New().String(),
+		ChatID:      chatID,
+		UploaderID:  user.UserID,
+		ContentType: info.ContentType,
+		Size:        int64(len(data)),
+		Width:       info.Width,
+		Height:      info.Height,
+	}
+	attachment.S3Key = services.ChatAttachmentKey(chatID, attachment.ID, info.ContentType)
+	attachment.ThumbnailKey = services.ChatAttachmentThumbnailKey(chatID, attachment.ID)
+
+	ctx := r.Context()
+	if _, err := h.S3Service.UploadObject(ctx, attachment.S3Key, bytes.NewReader(data), info.ContentType); err != nil {
+		utils.LogError("Error uploading chat attachment", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if _, err := h.S3Service.UploadObject(ctx, attachment.ThumbnailKey, bytes.NewReader(thumbnail), "image/jpeg"); err != nil {
+		utils.LogError("Error uploading chat attachment thumbnail", err)
+		h.deleteAttachmentObjects(ctx, attachment.S3Key)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	err = database.DB.QueryRow(`
+		INSERT INTO chat_attachments (id, chat_id, uploader_id, s3_key, thumbnail_key, content_type, size, width, height, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		RETURNING created_at
+	`, attachment.ID, chatID, user.UserID, attachment.S3Key, attachment.ThumbnailKey,
+		attachment.ContentType, attachment.Size, attachment.Width, attachment.Height).Scan(&attachment.CreatedAt)
+	if err != nil {
+		utils.LogError("Error saving chat attachment", err)
+		h.deleteAttachmentObjects(ctx, attachment.S3Key, attachment.ThumbnailKey)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if err := h.signAttachment(ctx, &attachment); err != nil {
+		utils.LogError("Error signing attachment URLs", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteCreated(w, "Attachment uploaded", attachment)
+}
+
+// GetAttachment returns an attachment with fresh signed URLs
+// @Summary Get chat attachment
+// @Description Get a chat attachment with freshly signed url and thumbnail_url, for when earlier ones expired. Only the two match participants can fetch attachments that were sent, and only the uploader those that weren't yet.
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param attachmentID path string true "Attachment ID"
+// @Success 200 {object} models.APIResponse{data=models.ChatAttachment} "Attachment retrieved"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid attachment ID"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Attachment not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/attachments/{attachmentID} [get]
+func (h *Handler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatID")
+	attachmentID := chi.URLParam(r, "attachmentID")
+	if _, err := uuid.Parse(attachmentID); err != nil {
+		utils.WriteErrorResponse(w, "Invalid attachment ID", http.StatusBadRequest)
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	var attachment models.ChatAttachment
+	var messageID sql.NullString
+	err := database.DB.QueryRow(`
+		SELECT a.id, a.chat_id, a.uploader_id, a.message_id, a.s3_key, a.thumbnail_key,
+		       a.content_type, a.size, a.width, a.height, a.created_at
+		FROM chat_attachments a
+		JOIN chats c ON c.id = a.chat_id
+		JOIN matches m ON m.id = c.match_id
+		WHERE a.id = $1 AND a.chat_id = $2
+		  AND (m.user1_id = $3 OR m.user2_id = $3) AND m.is_active = true
+		  AND (a.message_id IS NOT NULL OR a.uploader_id = $3)
+	`, attachmentID, chatID, user.UserID).Scan(&attachment.ID, &attachment.ChatID, &attachment.UploaderID, &messageID,
+		&attachment.S3Key, &attachment.ThumbnailKey, &attachment.ContentType, &attachment.Size,
+		&attachment.Width, &attachment.Height, &attachment.CreatedAt)
+	if err == sql.ErrNoRows {
+		utils.WriteNotFound(w, "Attachment not found")
+		return
+	}
+	if err != nil {
+		utils.LogError("Error loading chat attachment", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	attachment.MessageID = messageID.String
+
+	if err := h.signAttachment(r.Context(), &attachment); err != nil {
+		utils.LogError("Error signing attachment URLs", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Attachment retrieved", attachment)
+}
+
+// claimAttachment locks an unsent attachment the sender uploaded to the chat
+// and returns the message type it is sent as
+func claimAttachment(tx *sql.Tx, attachmentID, chatID, senderID string) (string, error) {
+	var contentType string
+	err := tx.QueryRow(`
+		SELECT content_type FROM chat_attachments
+		WHERE id = $1 AND chat_id = $2 AND uploader_id = $3 AND message_id IS NULL
+		FOR UPDATE
+	`, attachmentID, chatID, senderID).Scan(&contentType)
+	if err == sql.ErrNoRows {
+		return "", errAttachmentUnavailable
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load attachment: %w", err)
+	}
+
+	if contentType == "image/gif" {
+		return "gif", nil
+	}
+	return "image", nil
+}
+
+// attachAttachments loads the attachments of the given messages with signed URLs
+func (h *Handler) attachAttachments(ctx context.Context, messages []models.Message) error {
+	var ids []string
+	index := make(map[string]int)
+	for i, message := range messages {
+		if message.MessageType != "text" && message.DeletedAt == nil {
+			index[message.ID] = i
+			ids = append(ids, message.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, chat_id, uploader_id, message_id, s3_key, thumbnail_key,
+		       content_type, size, width, height, created_at
+		FROM chat_attachments
+		WHERE message_id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	for rows.Next() {
+		var attachment models.ChatAttachment
+		if err := rows.Scan(&attachment.ID, &attachment.ChatID, &attachment.UploaderID, &attachment.MessageID,
+			&attachment.S3Key, &attachment.ThumbnailKey, &attachment.ContentType, &attachment.Size,
+			&attachment.Width, &attachment.Height, &attachment.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		if err := h.signAttachment(ctx, &attachment); err != nil {
+			return err
+		}
+		messages[index[attachment.MessageID]].Attachment = &attachment
+	}
+	return rows.Err()
+}
+
+// removeAttachment deletes an unsent message's attachment and its stored objects
+func (h *Handler) removeAttachment(ctx context.Context, messageID string) {
+	var key, thumbnailKey string
+	err := database.DB.QueryRowContext(ctx, `
+		DELETE FROM chat_attachments WHERE message_id = $1
+		RETURNING s3_key, thumbnail_key
+	`, messageID).Scan(&key, &thumbnailKey)
+	if err == sql.ErrNoRows {
+		return
+	}
+	if err != nil {
+		utils.LogError("Error deleting chat attachment", err)
+		return
+	}
+	h.deleteAttachmentObjects(ctx, key, thumbnailKey)
+}
+
+// deleteAttachmentObjects removes stored attachment objects, logging failures
+func (h *Handler) deleteAttachmentObjects(ctx context.Context, keys ...string) {
+	if h.S3Service == nil {
+		return
+	}
+	if err := h.S3Service.DeleteImages(ctx, keys); err != nil {
+		utils.LogError("Error deleting chat attachment objects", err)
+	}
+}
+
+// signAttachment fills in presigned URLs for an attachment. Without storage
+// configured the URLs are left empty.
+func (h *Handler) signAttachment(ctx context.Context, attachment *models.ChatAttachment) error {
+	if h.S3Service == nil {
+		return nil
+	}
+
+	url, err := h.S3Service.GeneratePresignedDownloadURL(ctx, attachment.S3Key, attachmentURLTTL)
+	if err != nil {
+		return err
+	}
+	thumbnailURL, err := h.S3Service.GeneratePresignedDownloadURL(ctx, attachment.ThumbnailKey, attachmentURLTTL)
+	if err != nil {
+		return err
+	}
+
+	attachment.URL = url
+	attachment.ThumbnailURL = thumbnailURL
+	attachment.URLExpiresAt = time.Now().Add(attachmentURLTTL)
+	return nil
+}
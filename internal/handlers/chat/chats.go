@@ -41,7 +41,7 @@ func (h *Handler) GetChats(w http.ResponseWriter, r *http.Request) {
 	// Query to get chats with match and user information
 	query := `
 		SELECT 
-			c.id, c.match_id, c.last_message_at, c.created_at, c.updated_at,
+			c.id, c.match_id, c.last_message_at, c.last_seq, c.last_change_seq, c.created_at, c.updated_at,
 			m.user1_id, m.user2_id,
 			u1.first_name as user1_first_name, u1.last_name as user1_last_name,
 			u2.first_name as user2_first_name, u2.last_name as user2_last_name,
@@ -56,7 +56,7 @@ func (h *Handler) GetChats(w http.ResponseWriter, r *http.Request) {
 		LEFT JOIN messages msg ON msg.id = (
 			SELECT id FROM messages 
 			WHERE chat_id = c.id 
-			ORDER BY created_at DESC 
+			ORDER BY seq DESC 
 			LIMIT 1
 		)
 		WHERE (m.user1_id = $1 OR m.user2_id = $1) AND m.is_active = true
@@ -84,7 +84,7 @@ func (h *Handler) GetChats(w http.ResponseWriter, r *http.Request) {
 		var lastMessageCreatedAt time.Time
 
 		err := rows.Scan(
-			&chat.ID, &chat.MatchID, &chat.LastMessageAt, &chat.CreatedAt, &chat.UpdatedAt,
+			&chat.ID, &chat.MatchID, &chat.LastMessageAt, &chat.LastSeq, &chat.LastChangeSeq, &chat.CreatedAt, &chat.UpdatedAt,
 			&match.User1ID, &match.User2ID,
 			&user1FirstName, &user1LastName, &user2FirstName, &user2LastName,
 			&lastMessageID, &lastMessageContent, &lastMessageType, &lastMessageCreatedAt,
@@ -115,6 +115,7 @@ func (h *Handler) GetChats(w http.ResponseWriter, r *http.Request) {
 			chat.LastMessage = &models.Message{
 				ID:          lastMessageID,
 				ChatID:      chat.ID,
+				Seq:         chat.LastSeq,
 				Content:     lastMessageContent,
 				MessageType: lastMessageType,
 			}
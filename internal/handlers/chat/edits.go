@@ -0,0 +1,405 @@
+package chat
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// messageEditWindow is how long after sending a message can be edited
+const messageEditWindow = 15 * time.Minute
+
+// messageRef is what the edit, unsend and reaction handlers need to know
+// about a message before changing it
+type messageRef struct {
+	senderID    string
+	messageType string
+	createdAt   time.Time
+	deletedAt   *time.Time
+}
+
+// EditMessage replaces the content of a message
+// @Summary Edit message
+// @Description Replace the content of one of the caller's text messages, within 15 minutes of sending it. The previous content is kept in the message's edit history and both chat members are sent a message_edited event over WebSocket.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param messageID path string true "Message ID"
+// @Param request body models.EditMessageRequest true "New content"
+// @Success 200 {object} models.APIResponse{data=object{message=models.Message}} "Message edited"
+// @Failure 400 {object} models.ErrorResponse "Bad request - validation failed or not a text message"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Not the sender, or the edit window has passed"
+// @Failure 404 {object} models.ErrorResponse "Chat or message not found"
+// @Failure 409 {object} models.ErrorResponse "Message was unsent"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/messages/{messageID} [put]
+func (h *Handler) EditMessage(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID, messageID, ok := messageParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.EditMessageRequest
+	if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	ref, err := lookupMessage(chatID, messageID, user.UserID)
+	if err != nil {
+		utils.LogError("Error loading message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if ref == nil {
+		utils.WriteNotFound(w, "Chat or message not found")
+		return
+	}
+	if ref.senderID != user.UserID {
+		utils.WriteForbidden(w, "Only the sender can edit a message")
+		return
+	}
+	if ref.deletedAt != nil {
+		utils.WriteErrorResponse(w, "Message was unsent", http.StatusConflict)
+		return
+	}
+	if ref.messageType != "text" {
+		utils.WriteErrorResponse(w, "Only text messages can be edited", http.StatusBadRequest)
+		return
+	}
+	if time.Since(ref.createdAt) > messageEditWindow {
+		utils.WriteForbidden(w, "Messages can only be edited within 15 minutes of sending")
+		return
+	}
+
+	// Keep the previous content in the history and replace it in one step, so
+	// concurrent edits each record the version they replaced
+	now := time.Now()
+	result, err := database.DB.Exec(`
+		WITH previous AS (
+			SELECT id, content FROM messages
+			WHERE id = $1 AND deleted_at IS NULL
+			FOR UPDATE
+		), history AS (
+			INSERT INTO message_edits (message_id, content, edited_at)
+			SELECT id, content, $3 FROM previous
+		)
+		UPDATE messages m SET content = $2, edited_at = $3
+		FROM previous
+		WHERE m.id = previous.id
+	`, messageID, req.Content, now)
+	if err != nil {
+		utils.LogError("Error editing message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if edited, err := result.RowsAffected(); err == nil && edited == 0 {
+		utils.WriteErrorResponse(w, "Message was unsent", http.StatusConflict)
+		return
+	}
+	markChanged(messageID)
+
+	message, err := h.loadMessage(messageID, user.UserID)
+	if err != nil {
+		utils.LogError("Error loading edited message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	BroadcastMessageEvent(WebSocketMessage{
+		Type:      EventMessageEdited,
+		ChatID:    chatID,
+		Message:   message,
+		ChangeSeq: message.ChangeSeq,
+	})
+
+	utils.WriteSuccessResponse(w, "Message edited", map[string]interface{}{
+		"message": message,
+	})
+}
+
+// GetMessageEdits returns the edit history of a message
+// @Summary Get message edit history
+// @Description Get the earlier versions of an edited message, most recent first. Unsent messages have no history.
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param messageID path string true "Message ID"
+// @Success 200 {object} models.APIResponse{data=object{edits=[]models.MessageEdit}} "Edit history retrieved"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid message ID"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Chat or message not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/messages/{messageID}/edits [get]
+func (h *Handler) GetMessageEdits(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID, messageID, ok := messageParams(w, r)
+	if !ok {
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	ref, err := lookupMessage(chatID, messageID, user.UserID)
+	if err != nil {
+		utils.LogError("Error loading message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if ref == nil {
+		utils.WriteNotFound(w, "Chat or message not found")
+		return
+	}
+
+	rows, err := database.DB.Query(`
+		SELECT id, message_id, content, edited_at
+		FROM message_edits
+		WHERE message_id = $1
+		ORDER BY edited_at DESC
+	`, messageID)
+	if err != nil {
+		utils.LogError("Error querying message edits", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	edits := []models.MessageEdit{}
+	for rows.Next() {
+		var edit models.MessageEdit
+		if err := rows.Scan(&edit.ID, &edit.MessageID, &edit.Content, &edit.EditedAt); err != nil {
+			utils.LogError("Error scanning message edit", err)
+			continue
+		}
+		edits = append(edits, edit)
+	}
+
+	utils.WriteSuccessResponse(w, "Edit history retrieved", map[string]interface{}{
+		"edits": edits,
+	})
+}
+
+// DeleteMessage unsends a message for both chat members
+// @Summary Unsend message
+// @Description Unsend one of the caller's messages for everyone. The message stays in the chat as a tombstone with deleted_at set and its content cleared; its edit history, reactions and attachment are removed. Both chat members are sent a message_deleted event over WebSocket. Unsending a message again returns the tombstone.
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param messageID path string true "Message ID"
+// @Success 200 {object} models.APIResponse{data=object{message=models.Message}} "Message unsent"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid message ID"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Not the sender"
+// @Failure 404 {object} models.ErrorResponse "Chat or message not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/messages/{messageID} [delete]
+func (h *Handler) DeleteMessage(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID, messageID, ok := messageParams(w, r)
+	if !ok {
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	ref, err := lookupMessage(chatID, messageID, user.UserID)
+	if err != nil {
+		utils.LogError("Error loading message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if ref == nil {
+		utils.WriteNotFound(w, "Chat or message not found")
+		return
+	}
+	if ref.senderID != user.UserID {
+		utils.WriteForbidden(w, "Only the sender can unsend a message")
+		return
+	}
+
+	now := time.Now()
+	var unsent int
+	err = database.DB.QueryRow(`
+		WITH tombstone AS (
+			UPDATE messages SET content = '', gif = NULL, edited_at = NULL, deleted_at = $2
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING id
+		), history AS (
+			DELETE FROM message_edits WHERE message_id IN (SELECT id FROM tombstone)
+		), reactions AS (
+			DELETE FROM message_reactions WHERE message_id IN (SELECT id FROM tombstone)
+		)
+		SELECT COUNT(*) FROM tombstone
+	`, messageID, now).Scan(&unsent)
+	if err != nil {
+		utils.LogError("Error unsending message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	var changeSeq int64
+	if unsent > 0 {
+		changeSeq = markChanged(messageID)
+		h.removeAttachment(r.Context(), messageID)
+	}
+
+	message, err := h.loadMessage(messageID, user.UserID)
+	if err != nil {
+		utils.LogError("Error loading unsent message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if unsent > 0 {
+		BroadcastMessageEvent(WebSocketMessage{
+			Type:      EventMessageDeleted,
+			ChatID:    chatID,
+			MessageID: messageID,
+			UserID:    user.UserID,
+			Timestamp: &now,
+			ChangeSeq: changeSeq,
+		})
+	}
+
+	utils.WriteSuccessResponse(w, "Message unsent", map[string]interface{}{
+		"message": message,
+	})
+}
+
+// messageParams reads the chat and message IDs from the URL, writing a bad
+// request response if either is missing or malformed
+func messageParams(w http.ResponseWriter, r *http.Request) (chatID, messageID string, ok bool) {
+	chatID = chi.URLParam(r, "chatID")
+	if chatID == "" {
+		utils.WriteErrorResponse(w, "Chat ID is required", http.StatusBadRequest)
+		return "", "", false
+	}
+
+	messageID = chi.URLParam(r, "messageID")
+	if _, err := uuid.Parse(messageID); err != nil {
+		utils.WriteErrorResponse(w, "Invalid message ID", http.StatusBadRequest)
+		return "", "", false
+	}
+	return chatID, messageID, true
+}
+
+// lookupMessage returns a message in one of the user's active chats, or nil
+// if there is no such message
+func lookupMessage(chatID, messageID, userID string) (*messageRef, error) {
+	var ref messageRef
+	err := database.DB.QueryRow(`
+		SELECT msg.sender_id, msg.message_type, msg.created_at, msg.deleted_at
+		FROM messages msg
+		JOIN chats c ON c.id = msg.chat_id
+		JOIN matches m ON m.id = c.match_id
+		WHERE msg.id = $1 AND msg.chat_id = $2
+		  AND (m.user1_id = $3 OR m.user2_id = $3) AND m.is_active = true AND c.is_active = true
+	`, messageID, chatID, userID).Scan(&ref.senderID, &ref.messageType, &ref.createdAt, &ref.deletedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up message: %w", err)
+	}
+	return &ref, nil
+}
+
+// markChanged moves a message to the end of its chat's change sequence after
+// an edit, unsend or reaction change, so clients catching up replay it, and
+// returns its new change sequence number. Failures are logged; the change
+// itself is already stored.
+func markChanged(messageID string) int64 {
+	var changeSeq int64
+	err := database.DB.QueryRow(`
+		WITH bumped AS (
+			UPDATE chats SET last_change_seq = last_change_seq + 1
+			WHERE id = (SELECT chat_id FROM messages WHERE id = $1)
+			RETURNING last_change_seq
+		)
+		UPDATE messages SET change_seq = bumped.last_change_seq
+		FROM bumped
+		WHERE messages.id = $1
+		RETURNING messages.change_seq
+	`, messageID).Scan(&changeSeq)
+	if err != nil {
+		utils.LogError("Error recording message change", err)
+	}
+	return changeSeq
+}
+
+// loadMessage loads a message with its reactions and attachment as the viewer
+// sees it
+func (h *Handler) loadMessage(messageID, viewerID string) (*models.Message, error) {
+	var message models.Message
+	var clientMessageID sql.NullString
+	var gifJSON []byte
+	err := database.DB.QueryRow(`
+		SELECT id, chat_id, sender_id, seq, change_seq, content, message_type,
+		       CASE WHEN sender_id = $2 THEN read_at IS NOT NULL ELSE is_read END,
+		       delivered_at, read_at, edited_at, deleted_at, created_at,
+		       CASE WHEN sender_id = $2 THEN client_message_id END, gif
+		FROM messages WHERE id = $1
+	`, messageID, viewerID).Scan(&message.ID, &message.ChatID, &message.SenderID, &message.Seq, &message.ChangeSeq, &message.Content,
+		&message.MessageType, &message.IsRead, &message.DeliveredAt, &message.ReadAt, &message.EditedAt,
+		&message.DeletedAt, &message.CreatedAt, &clientMessageID, &gifJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load message: %w", err)
+	}
+	message.ClientMessageID = clientMessageID.String
+	message.GIF = decodeGIF(gifJSON)
+
+	messages := []models.Message{message}
+	if err := attachReactions(messages); err != nil {
+		return nil, err
+	}
+	if err := h.attachAttachments(context.Background(), messages); err != nil {
+		return nil, err
+	}
+	return &messages[0], nil
+}
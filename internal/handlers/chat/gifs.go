@@ -0,0 +1,163 @@
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/gifs"
+	"matching-api/pkg/utils"
+)
+
+const (
+	// defaultGIFLimit and maxGIFLimit bound a page of GIF results
+	defaultGIFLimit = 25
+	maxGIFLimit     = 50
+	// maxGIFOffset keeps paging within what providers serve
+	maxGIFOffset = 4999
+	// maxGIFQueryLength bounds search terms
+	maxGIFQueryLength = 100
+)
+
+var (
+	// errGIFRequired is returned for gif messages without a GIF ID, unless
+	// they send an uploaded GIF attachment
+	errGIFRequired = errors.New("gif messages must include a gif_id from GIF search or an attachment_id")
+	// errGIFUnavailable is returned for GIF IDs the provider did not issue
+	errGIFUnavailable = errors.New("gif not found")
+	// errGIFWithAttachment is returned for messages sending both kinds of media
+	errGIFWithAttachment = errors.New("a message can't include both a gif_id and an attachment_id")
+)
+
+// SearchGIFs searches the GIF provider
+// @Summary Search GIFs
+// @Description Search GIFs to send in a chat. Results come from the configured GIF provider, are cached briefly and never exceed the server's maximum content rating. Send one by passing its id as gif_id.
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Search terms"
+// @Param rating query string false "Highest content rating to include: g, pg, pg-13 or r (capped at the server maximum)"
+// @Param limit query int false "Number of GIFs (max 50)" default(25)
+// @Param offset query int false "Offset for pagination, from next_offset" default(0)
+// @Success 200 {object} models.APIResponse{data=models.GIFPage} "GIFs retrieved successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid query"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 502 {object} models.ErrorResponse "GIF provider unavailable"
+// @Failure 503 {object} models.ErrorResponse "GIF search not configured"
+// @Router /chats/gifs/search [get]
+func (h *Handler) SearchGIFs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		utils.WriteErrorResponse(w, "Search query is required", http.StatusBadRequest)
+		return
+	}
+	if len(query) > maxGIFQueryLength {
+		utils.WriteErrorResponse(w, fmt.Sprintf("Search query must be at most %d characters", maxGIFQueryLength), http.StatusBadRequest)
+		return
+	}
+
+	h.writeGIFPage(w, r, func(ctx context.Context, rating string, limit, offset int) (*models.GIFPage, error) {
+		return h.GIFs.Search(ctx, query, rating, limit, offset)
+	})
+}
+
+// TrendingGIFs lists trending GIFs from the GIF provider
+// @Summary Trending GIFs
+// @Description Get currently trending GIFs from the configured GIF provider, cached briefly and never exceeding the server's maximum content rating.
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param rating query string false "Highest content rating to include: g, pg, pg-13 or r (capped at the server maximum)"
+// @Param limit query int false "Number of GIFs (max 50)" default(25)
+// @Param offset query int false "Offset for pagination, from next_offset" default(0)
+// @Success 200 {object} models.APIResponse{data=models.GIFPage} "GIFs retrieved successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid query"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 502 {object} models.ErrorResponse "GIF provider unavailable"
+// @Failure 503 {object} models.ErrorResponse "GIF search not configured"
+// @Router /chats/gifs/trending [get]
+func (h *Handler) TrendingGIFs(w http.ResponseWriter, r *http.Request) {
+	h.writeGIFPage(w, r, h.GIFs.Trending)
+}
+
+// writeGIFPage reads the rating and paging parameters, fetches a page of GIFs
+// and writes it
+func (h *Handler) writeGIFPage(w http.ResponseWriter, r *http.Request, fetch func(ctx context.Context, rating string, limit, offset int) (*models.GIFPage, error)) {
+	if _, ok := middleware.GetUserFromContext(r.Context()); !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if h.GIFs == nil {
+		utils.WriteErrorResponse(w, "GIF search is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	rating, err := h.GIFs.Rating(r.URL.Query().Get("rating"))
+	if err != nil {
+		utils.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	limit, err := utils.GetQueryParamInt(r, "limit", defaultGIFLimit)
+	if err != nil || limit < 1 || limit > maxGIFLimit {
+		utils.WriteErrorResponse(w, fmt.Sprintf("Limit must be between 1 and %d", maxGIFLimit), http.StatusBadRequest)
+		return
+	}
+	offset, err := utils.GetQueryParamInt(r, "offset", 0)
+	if err != nil || offset < 0 || offset > maxGIFOffset {
+		utils.WriteErrorResponse(w, fmt.Sprintf("Offset must be between 0 and %d", maxGIFOffset), http.StatusBadRequest)
+		return
+	}
+
+	page, err := fetch(r.Context(), rating, limit, offset)
+	if err != nil {
+		utils.LogError("Error fetching GIFs", err)
+		utils.WriteErrorResponse(w, "GIF provider unavailable", http.StatusBadGateway)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "GIFs retrieved successfully", page)
+}
+
+// resolveGIF looks up the GIF a message sends. Only IDs the provider issued
+// are accepted, so messages can't carry arbitrary links.
+func (h *Handler) resolveGIF(ctx context.Context, id string) (*models.GIF, error) {
+	if h.GIFs == nil {
+		return nil, errGIFUnavailable
+	}
+
+	gif, err := h.GIFs.Get(ctx, id)
+	if errors.Is(err, gifs.ErrNotFound) {
+		return nil, errGIFUnavailable
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up gif: %w", err)
+	}
+	return gif, nil
+}
+
+// decodeGIF decodes the gif column of a message, nil for messages without one
+func decodeGIF(data []byte) *models.GIF {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var gif models.GIF
+	if err := json.Unmarshal(data, &gif); err != nil {
+		utils.LogError("Error decoding message gif", err)
+		return nil
+	}
+	return &gif
+}
+
+// isRejectedMessage reports whether sending a message failed because of what
+// the request referenced, rather than a server error
+func isRejectedMessage(err error) bool {
+	return errors.Is(err, errAttachmentUnavailable) || errors.Is(err, errAttachmentRequired) ||
+		errors.Is(err, errGIFRequired) || errors.Is(err, errGIFUnavailable) || errors.Is(err, errGIFWithAttachment)
+}
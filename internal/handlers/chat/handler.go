@@ -2,17 +2,26 @@ package chat
 
 import (
 	"matching-api/internal/handlers/shared"
+	"matching-api/internal/middleware"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/gifs"
 	"matching-api/pkg/services"
 )
 
 // Handler handles chat-related requests
 type Handler struct {
 	shared.BaseHandler
+	S3Service  *services.S3Service
+	GIFs       *gifs.Service // Nil when GIF search is not configured
+	rateLimits *middleware.RateLimits
 }
 
 // NewHandler creates a new chat handler
-func NewHandler(redisService *services.RedisService) *Handler {
+func NewHandler(s3Service *services.S3Service, redisService *services.RedisService, cacheStore cache.Cache, rateLimits *middleware.RateLimits, gifService *gifs.Service) *Handler {
 	return &Handler{
-		BaseHandler: shared.NewBaseHandler(redisService),
+		BaseHandler: shared.NewBaseHandler(redisService, cacheStore),
+		S3Service:   s3Service,
+		GIFs:        gifService,
+		rateLimits:  rateLimits,
 	}
 }
@@ -1,21 +1,26 @@
 package chat
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"matching-api/internal/database"
 	"matching-api/internal/middleware"
 	"matching-api/internal/models"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/utils"
 )
 
 // GetMessages retrieves messages for a specific chat
 // @Summary Get chat messages
-// @Description Retrieve messages for a specific chat conversation with pagination
+// @Description Retrieve messages for a specific chat conversation with pagination, newest first. With since_seq, messages after that sequence number are returned oldest first instead, for catching up after a reconnect; page on by passing the last seq received until it reaches last_seq. With changed_since_seq, messages edited, unsent or reacted to after that change sequence number are returned in the order they changed; page on by passing the last change_seq received until it reaches last_change_seq. Fetching marks the other user's messages as delivered; use POST /chats/{chatID}/read to mark them read. On the caller's own messages is_read and read_at tell whether the other user has read them, unless they turned read receipts off.
 // @Tags Chat
 // @Accept json
 // @Produce json
@@ -23,8 +28,10 @@ import (
 // @Param chatID path string true "Chat ID"
 // @Param limit query int false "Number of messages per page (max 100)" default(50)
 // @Param offset query int false "Offset for pagination" default(0)
-// @Success 200 {object} models.APIResponse{data=object{messages=[]models.Message,total=int,limit=int,offset=int}} "Messages retrieved successfully"
-// @Failure 400 {object} models.ErrorResponse "Bad request - invalid chat ID"
+// @Param since_seq query int false "Only return messages after this sequence number, oldest first (offset is ignored)"
+// @Param changed_since_seq query int false "Only return messages changed after this change sequence number, in the order they changed (offset is ignored)"
+// @Success 200 {object} models.APIResponse{data=object{messages=[]models.Message,total=int,limit=int,offset=int,last_seq=int,last_change_seq=int}} "Messages retrieved successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid chat ID, or since_seq combined with changed_since_seq"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
 // @Failure 403 {object} models.ErrorResponse "Forbidden - no access to chat"
 // @Failure 404 {object} models.ErrorResponse "Chat not found"
@@ -52,26 +59,23 @@ func (h *Handler) GetMessages(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// First verify that the user has access to this chat
-	var matchExists bool
+	var lastSeq, lastChangeSeq int64
 	checkQuery := `
-		SELECT EXISTS(
-			SELECT 1 FROM chats c
-			JOIN matches m ON c.match_id = m.id
-			WHERE c.id = $1 AND (m.user1_id = $2 OR m.user2_id = $2) AND m.is_active = true
-		)
+		SELECT c.last_seq, c.last_change_seq FROM chats c
+		JOIN matches m ON c.match_id = m.id
+		WHERE c.id = $1 AND (m.user1_id = $2 OR m.user2_id = $2) AND m.is_active = true
 	`
-	err := database.DB.QueryRow(checkQuery, chatID, user.UserID).Scan(&matchExists)
+	err := database.DB.QueryRow(checkQuery, chatID, user.UserID).Scan(&lastSeq, &lastChangeSeq)
+	if err == sql.ErrNoRows {
+		utils.WriteErrorResponse(w, "Chat not found or access denied", http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		utils.LogError("Error checking chat access", err)
 		utils.WriteInternalError(w, err)
 		return
 	}
 
-	if !matchExists {
-		utils.WriteErrorResponse(w, "Chat not found or access denied", http.StatusNotFound)
-		return
-	}
-
 	// Get pagination parameters
 	limit := 50 // default limit
 	offset := 0
@@ -86,19 +90,59 @@ func (h *Handler) GetMessages(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Query messages
+	// Catching up after a reconnect pages forward from the last seen message
+	// or change instead of back from the newest
+	args := []any{chatID, limit, offset, user.UserID}
+	seqFilter := ""
+	orderBy := "m.seq DESC"
+	sinceSeqStr := r.URL.Query().Get("since_seq")
+	changedSinceSeqStr := r.URL.Query().Get("changed_since_seq")
+	if sinceSeqStr != "" && changedSinceSeqStr != "" {
+		utils.WriteErrorResponse(w, "since_seq and changed_since_seq can't be combined", http.StatusBadRequest)
+		return
+	}
+	if sinceSeqStr != "" {
+		sinceSeq, err := strconv.ParseInt(sinceSeqStr, 10, 64)
+		if err != nil || sinceSeq < 0 {
+			utils.WriteErrorResponse(w, "since_seq must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		offset = 0
+		args[2] = offset
+		args = append(args, sinceSeq)
+		seqFilter = "AND m.seq > $5"
+		orderBy = "m.seq ASC"
+	}
+	if changedSinceSeqStr != "" {
+		changedSinceSeq, err := strconv.ParseInt(changedSinceSeqStr, 10, 64)
+		if err != nil || changedSinceSeq < 0 {
+			utils.WriteErrorResponse(w, "changed_since_seq must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		offset = 0
+		args[2] = offset
+		args = append(args, changedSinceSeq)
+		seqFilter = "AND m.change_seq > $5"
+		orderBy = "m.change_seq ASC"
+	}
+
+	// Query messages. The caller's own messages count as read once a read
+	// receipt came back, not when the other user's unread flag cleared.
 	query := `
 		SELECT 
-			m.id, m.chat_id, m.sender_id, m.content, m.message_type, m.is_read, m.created_at,
+			m.id, m.chat_id, m.sender_id, m.seq, m.change_seq, m.content, m.message_type,
+			CASE WHEN m.sender_id = $4 THEN m.read_at IS NOT NULL ELSE m.is_read END,
+			m.delivered_at, m.read_at, m.edited_at, m.deleted_at, m.created_at,
+			CASE WHEN m.sender_id = $4 THEN m.client_message_id END, m.gif,
 			u.first_name, u.last_name
 		FROM messages m
 		JOIN users u ON m.sender_id = u.id
-		WHERE m.chat_id = $1
-		ORDER BY m.created_at DESC
+		WHERE m.chat_id = $1 ` + seqFilter + `
+		ORDER BY ` + orderBy + `
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := database.DB.Query(query, chatID, limit, offset)
+	rows, err := database.DB.Query(query, args...)
 	if err != nil {
 		utils.LogError("Error querying messages", err)
 		utils.WriteInternalError(w, err)
@@ -114,52 +158,72 @@ func (h *Handler) GetMessages(w http.ResponseWriter, r *http.Request) {
 	for rows.Next() {
 		var message models.Message
 		var senderFirstName, senderLastName string
+		var clientMessageID sql.NullString
+		var gifJSON []byte
 
 		err := rows.Scan(
-			&message.ID, &message.ChatID, &message.SenderID, &message.Content,
-			&message.MessageType, &message.IsRead, &message.CreatedAt,
-			&senderFirstName, &senderLastName,
+			&message.ID, &message.ChatID, &message.SenderID, &message.Seq, &message.ChangeSeq, &message.Content,
+			&message.MessageType, &message.IsRead, &message.DeliveredAt, &message.ReadAt,
+			&message.EditedAt, &message.DeletedAt, &message.CreatedAt,
+			&clientMessageID, &gifJSON, &senderFirstName, &senderLastName,
 		)
 		if err != nil {
 			utils.LogError("Error scanning message row", err)
 			continue
 		}
+		message.ClientMessageID = clientMessageID.String
+		message.GIF = decodeGIF(gifJSON)
 
 		messages = append(messages, message)
 	}
 
-	// Mark messages as read for the current user
-	markReadQuery := `
-		UPDATE messages 
-		SET is_read = true 
-		WHERE chat_id = $1 AND sender_id != $2 AND is_read = false
-	`
-	_, err = database.DB.Exec(markReadQuery, chatID, user.UserID)
-	if err != nil {
-		utils.LogError("Error marking messages as read", err)
+	if err := attachReactions(messages); err != nil {
+		utils.LogError("Error loading reactions", err)
+	}
+	if err := h.attachAttachments(r.Context(), messages); err != nil {
+		utils.LogError("Error loading attachments", err)
+	}
+
+	// The other user's messages on this page have now reached this user
+	var receivedIDs []string
+	for _, message := range messages {
+		if message.SenderID != user.UserID && message.DeliveredAt == nil {
+			receivedIDs = append(receivedIDs, message.ID)
+		}
+	}
+	if len(receivedIDs) > 0 {
+		deliveredAt, delivered := h.markDelivered(chatID, user.UserID, receivedIDs)
+		for i := range messages {
+			if delivered[messages[i].ID] {
+				messages[i].DeliveredAt = &deliveredAt
+			}
+		}
 	}
 
 	utils.WriteSuccessResponse(w, "Messages retrieved successfully", map[string]interface{}{
-		"messages": messages,
-		"total":    len(messages),
-		"limit":    limit,
-		"offset":   offset,
+		"messages":        messages,
+		"total":           len(messages),
+		"limit":           limit,
+		"offset":          offset,
+		"last_seq":        lastSeq,
+		"last_change_seq": lastChangeSeq,
 	})
 }
 
 // SendMessage sends a new message in a chat
 // @Summary Send message
-// @Description Send a message in a chat conversation
+// @Description Send a message in a chat conversation. Clients can pass a client_message_id of their choosing; retrying with the same ID returns the message already stored instead of sending it twice. To send an image, upload it with POST /chats/{chatID}/attachments and pass its attachment_id; content is then an optional caption and the message type follows the image.
 // @Tags Chat
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param chatID path string true "Chat ID"
 // @Param request body models.MessageRequest true "Message data"
-// @Success 201 {object} models.APIResponse{data=models.Message} "Message sent successfully"
+// @Success 200 {object} models.APIResponse{data=object{message=models.Message}} "Message already sent"
+// @Success 201 {object} models.APIResponse{data=object{message=models.Message}} "Message sent successfully"
 // @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
-// @Failure 403 {object} models.ErrorResponse "Forbidden - no access to chat"
+// @Failure 404 {object} models.ErrorResponse "Chat not found or access denied"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /chats/{chatID}/messages [post]
 func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
@@ -184,79 +248,244 @@ func (h *Handler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Set default message type
-	if req.MessageType == "" {
-		req.MessageType = "text"
-	}
-
 	// Get database connection
 	if database.DB == nil {
 		utils.WriteInternalError(w, nil)
 		return
 	}
 
-	// First verify that the user has access to this chat
-	var matchExists bool
-	checkQuery := `
-		SELECT EXISTS(
-			SELECT 1 FROM chats c
-			JOIN matches m ON c.match_id = m.id
-			WHERE c.id = $1 AND (m.user1_id = $2 OR m.user2_id = $2) AND m.is_active = true
-		)
-	`
-	err := database.DB.QueryRow(checkQuery, chatID, user.UserID).Scan(&matchExists)
+	message, created, err := h.createMessage(r.Context(), user.UserID, chatID, req)
+	if isRejectedMessage(err) {
+		utils.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
-		utils.LogError("Error checking chat access", err)
+		utils.LogError("Error sending message", err)
 		utils.WriteInternalError(w, err)
 		return
 	}
-
-	if !matchExists {
+	if message == nil {
 		utils.WriteErrorResponse(w, "Chat not found or access denied", http.StatusNotFound)
 		return
 	}
 
-	// Insert the message
-	var messageID string
-	insertQuery := `
-		INSERT INTO messages (id, chat_id, sender_id, content, message_type, is_read, created_at)
-		VALUES (gen_random_uuid(), $1, $2, $3, $4, false, NOW())
-		RETURNING id, created_at
+	if !created {
+		utils.WriteSuccessResponse(w, "Message already sent", map[string]interface{}{
+			"message": message,
+		})
+		return
+	}
+
+	utils.WriteCreated(w, "Message sent successfully", map[string]interface{}{
+		"message": message,
+	})
+}
+
+// errAttachmentRequired is returned for image messages without an attachment
+var errAttachmentRequired = errors.New("image messages must include an attachment_id")
+
+// createMessage stores a message from the user in a chat and pushes it to both
+// members. A message whose client ID the sender already used is not stored
+// again; the stored one is returned with created set to false. It returns nil
+// if the chat does not exist, is inactive or is not the user's.
+func (h *Handler) createMessage(ctx context.Context, userID, chatID string, req models.MessageRequest) (*models.Message, bool, error) {
+	// The attachment would set the type while the GIF is stored alongside it
+	if req.GIFID != "" && req.AttachmentID != "" {
+		return nil, false, errGIFWithAttachment
+	}
+
+	// Set default message type
+	if req.GIFID != "" {
+		req.MessageType = "gif"
+	}
+	if req.MessageType == "" {
+		req.MessageType = "text"
+	}
+	if req.MessageType == "image" && req.AttachmentID == "" {
+		return nil, false, errAttachmentRequired
+	}
+	if req.MessageType == "gif" && req.AttachmentID == "" && req.GIFID == "" {
+		return nil, false, errGIFRequired
+	}
+
+	// First verify that the user has access to this chat
+	var user1ID, user2ID string
+	checkQuery := `
+		SELECT m.user1_id, m.user2_id FROM chats c
+		JOIN matches m ON c.match_id = m.id
+		WHERE c.id = $1 AND (m.user1_id = $2 OR m.user2_id = $2) AND m.is_active = true AND c.is_active = true
 	`
+	err := database.DB.QueryRow(checkQuery, chatID, userID).Scan(&user1ID, &user2ID)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to check chat access: %w", err)
+	}
 
-	var createdAt time.Time
-	err = database.DB.QueryRow(insertQuery, chatID, user.UserID, req.Content, req.MessageType).Scan(&messageID, &createdAt)
+	// A retry is answered with the message sent the first time, before its
+	// attachment is found to be taken
+	if req.ClientMessageID != "" {
+		existing, err := h.getMessageByClientID(userID, req.ClientMessageID)
+		if existing != nil || err != nil {
+			return existing, false, err
+		}
+	}
+
+	// The provider's copy of the GIF is stored with the message
+	var gif *models.GIF
+	var gifColumn any
+	if req.GIFID != "" {
+		if gif, err = h.resolveGIF(ctx, req.GIFID); err != nil {
+			return nil, false, err
+		}
+		data, err := json.Marshal(gif)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to encode gif: %w", err)
+		}
+		gifColumn = string(data)
+	}
+
+	clientMessageID := sql.NullString{String: req.ClientMessageID, Valid: req.ClientMessageID != ""}
+
+	tx, err := database.DB.Begin()
 	if err != nil {
-		utils.LogError("Error inserting message", err)
-		utils.WriteInternalError(w, err)
-		return
+		return nil, false, fmt.Errorf("failed to start message transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			utils.LogError("Error rolling back message transaction", err)
+		}
+	}()
+
+	if req.AttachmentID != "" {
+		if req.MessageType, err = claimAttachment(tx, req.AttachmentID, chatID, userID); err != nil {
+			return nil, false, err
+		}
 	}
 
-	// Update chat's last_message_at
+	// Insert the message, unless the client already sent it
+	message := models.Message{
+		ChatID:          chatID,
+		SenderID:        userID,
+		ClientMessageID: req.ClientMessageID,
+		Content:         req.Content,
+		MessageType:     req.MessageType,
+		GIF:             gif,
+	}
+
+	// Take the chat's next sequence number. The chat row stays locked until
+	// commit, so messages become visible in sequence order and a reader
+	// catching up from a sequence number never skips one.
 	updateChatQuery := `
 		UPDATE chats 
-		SET last_message_at = NOW(), updated_at = NOW()
+		SET last_seq = last_seq + 1, last_message_at = NOW(), updated_at = NOW()
 		WHERE id = $1
+		RETURNING last_seq
 	`
-	_, err = database.DB.Exec(updateChatQuery, chatID)
+	if err := tx.QueryRow(updateChatQuery, chatID).Scan(&message.Seq); err != nil {
+		return nil, false, fmt.Errorf("failed to allocate message sequence: %w", err)
+	}
+
+	insertQuery := `
+		INSERT INTO messages (id, chat_id, sender_id, seq, client_message_id, content, message_type, gif, is_read, created_at)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, false, NOW())
+		ON CONFLICT (sender_id, client_message_id) WHERE client_message_id IS NOT NULL DO NOTHING
+		RETURNING id, created_at
+	`
+	err = tx.QueryRow(insertQuery, chatID, userID, message.Seq, clientMessageID, req.Content, req.MessageType, gifColumn).Scan(&message.ID, &message.CreatedAt)
+	if err == sql.ErrNoRows {
+		// Rolling back returns the sequence number
+		if err := tx.Rollback(); err != nil {
+			return nil, false, fmt.Errorf("failed to roll back duplicate message: %w", err)
+		}
+		existing, err := h.getMessageByClientID(userID, req.ClientMessageID)
+		if existing == nil && err == nil {
+			err = fmt.Errorf("message with client ID %q conflicted but was not found", req.ClientMessageID)
+		}
+		return existing, false, err
+	}
 	if err != nil {
-		utils.LogError("Error updating chat timestamp", err)
+		return nil, false, fmt.Errorf("failed to insert message: %w", err)
 	}
 
-	// Create response message
-	message := models.Message{
-		ID:          messageID,
-		ChatID:      chatID,
-		SenderID:    user.UserID,
-		Content:     req.Content,
-		MessageType: req.MessageType,
-		IsRead:      false,
+	if req.AttachmentID != "" {
+		if _, err := tx.Exec(`UPDATE chat_attachments SET message_id = $1 WHERE id = $2`, message.ID, req.AttachmentID); err != nil {
+			return nil, false, fmt.Errorf("failed to attach attachment: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit message: %w", err)
+	}
+
+	if req.AttachmentID != "" {
+		messages := []models.Message{message}
+		if err := h.attachAttachments(context.Background(), messages); err != nil {
+			utils.LogError("Error loading sent attachment", err)
+		}
+		message = messages[0]
+	}
+
+	// A conversation has started, so the match no longer expires
+	_, err = database.DB.Exec(`
+		UPDATE matches SET expires_at = NULL
+		WHERE id = (SELECT match_id FROM chats WHERE id = $1) AND expires_at IS NOT NULL
+	`, chatID)
+	if err != nil {
+		utils.LogError("Error clearing match expiry", err)
+	}
+
+	// The chat moved to the top of both users' match lists
+	if err := h.Cache.InvalidateTags(cache.MatchesTag(user1ID), cache.MatchesTag(user2ID)); err != nil {
+		utils.LogError("Failed to invalidate match cache", err)
 	}
 
 	// Broadcast message to WebSocket connections
 	BroadcastMessage(chatID, &message)
 
-	utils.WriteCreated(w, "Message sent successfully", map[string]interface{}{
-		"message": message,
-	})
+	// A connected recipient gets the message right away
+	recipientID := user1ID
+	if recipientID == userID {
+		recipientID = user2ID
+	}
+	if hub.isOnline(recipientID) {
+		if deliveredAt, delivered := h.markDelivered(chatID, recipientID, []string{message.ID}); delivered[message.ID] {
+			message.DeliveredAt = &deliveredAt
+		}
+	}
+
+	return &message, true, nil
+}
+
+// getMessageByClientID loads the message a sender stored under a client ID,
+// or nil if there is none
+func (h *Handler) getMessageByClientID(senderID, clientMessageID string) (*models.Message, error) {
+	var message models.Message
+	var clientID sql.NullString
+	var gifJSON []byte
+	err := database.DB.QueryRow(`
+		SELECT id, chat_id, sender_id, seq, client_message_id, content, message_type,
+		       read_at IS NOT NULL, delivered_at, read_at, edited_at, deleted_at, created_at, gif
+		FROM messages WHERE sender_id = $1 AND client_message_id = $2
+	`, senderID, clientMessageID).Scan(&message.ID, &message.ChatID, &message.SenderID, &message.Seq, &clientID,
+		&message.Content, &message.MessageType, &message.IsRead, &message.DeliveredAt, &message.ReadAt,
+		&message.EditedAt, &message.DeletedAt, &message.CreatedAt, &gifJSON)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sent message: %w", err)
+	}
+	message.ClientMessageID = clientID.String
+	message.GIF = decodeGIF(gifJSON)
+
+	messages := []models.Message{message}
+	if err := attachReactions(messages); err != nil {
+		return nil, err
+	}
+	if err := h.attachAttachments(context.Background(), messages); err != nil {
+		return nil, err
+	}
+	return &messages[0], nil
 }
@@ -0,0 +1,211 @@
+package chat
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/lib/pq"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// SetReaction sets the caller's reaction to a message
+// @Summary React to message
+// @Description Set the caller's emoji reaction to a message in one of their chats, replacing any earlier reaction of theirs. Both chat members are sent a reaction_added event over WebSocket.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param messageID path string true "Message ID"
+// @Param request body models.ReactionRequest true "Reaction"
+// @Success 200 {object} models.APIResponse{data=models.MessageReaction} "Reaction saved"
+// @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Chat or message not found"
+// @Failure 409 {object} models.ErrorResponse "Message was unsent"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/messages/{messageID}/reaction [put]
+func (h *Handler) SetReaction(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID, messageID, ok := messageParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req models.ReactionRequest
+	if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	ref, err := lookupMessage(chatID, messageID, user.UserID)
+	if err != nil {
+		utils.LogError("Error loading message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if ref == nil {
+		utils.WriteNotFound(w, "Chat or message not found")
+		return
+	}
+	if ref.deletedAt != nil {
+		utils.WriteErrorResponse(w, "Message was unsent", http.StatusConflict)
+		return
+	}
+
+	// Only react while the message is still there, so an unsend racing the
+	// reaction doesn't leave one behind on the tombstone
+	reaction := models.MessageReaction{MessageID: messageID, UserID: user.UserID}
+	err = database.DB.QueryRow(`
+		INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+		SELECT id, $2, $3, NOW() FROM messages WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT (message_id, user_id) DO UPDATE SET emoji = EXCLUDED.emoji, created_at = EXCLUDED.created_at
+		RETURNING emoji, created_at
+	`, messageID, user.UserID, req.Emoji).Scan(&reaction.Emoji, &reaction.CreatedAt)
+	if err == sql.ErrNoRows {
+		utils.WriteErrorResponse(w, "Message was unsent", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		utils.LogError("Error saving reaction", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	changeSeq := markChanged(messageID)
+	BroadcastMessageEvent(WebSocketMessage{
+		Type:      EventReactionAdded,
+		ChatID:    chatID,
+		MessageID: messageID,
+		UserID:    user.UserID,
+		Emoji:     reaction.Emoji,
+		Timestamp: &reaction.CreatedAt,
+		ChangeSeq: changeSeq,
+	})
+
+	utils.WriteSuccessResponse(w, "Reaction saved", reaction)
+}
+
+// RemoveReaction removes the caller's reaction to a message
+// @Summary Remove reaction
+// @Description Remove the caller's reaction to a message. Both chat members are sent a reaction_removed event over WebSocket.
+// @Tags Chat
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param messageID path string true "Message ID"
+// @Success 200 {object} models.APIResponse "Reaction removed"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid message ID"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Chat, message or reaction not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/messages/{messageID}/reaction [delete]
+func (h *Handler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID, messageID, ok := messageParams(w, r)
+	if !ok {
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	ref, err := lookupMessage(chatID, messageID, user.UserID)
+	if err != nil {
+		utils.LogError("Error loading message", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if ref == nil {
+		utils.WriteNotFound(w, "Chat or message not found")
+		return
+	}
+
+	var emoji string
+	err = database.DB.QueryRow(`
+		DELETE FROM message_reactions WHERE message_id = $1 AND user_id = $2
+		RETURNING emoji
+	`, messageID, user.UserID).Scan(&emoji)
+	if err == sql.ErrNoRows {
+		utils.WriteNotFound(w, "Reaction not found")
+		return
+	}
+	if err != nil {
+		utils.LogError("Error removing reaction", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	changeSeq := markChanged(messageID)
+	BroadcastMessageEvent(WebSocketMessage{
+		Type:      EventReactionRemoved,
+		ChatID:    chatID,
+		MessageID: messageID,
+		UserID:    user.UserID,
+		Emoji:     emoji,
+		ChangeSeq: changeSeq,
+	})
+
+	utils.WriteSuccessResponse(w, "Reaction removed", nil)
+}
+
+// attachReactions loads the reactions to the given messages, oldest first
+func attachReactions(messages []models.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(messages))
+	ids := make([]string, 0, len(messages))
+	for i, message := range messages {
+		index[message.ID] = i
+		ids = append(ids, message.ID)
+	}
+
+	rows, err := database.DB.Query(`
+		SELECT message_id, user_id, emoji, created_at
+		FROM message_reactions
+		WHERE message_id = ANY($1)
+		ORDER BY created_at
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to query reactions: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	for rows.Next() {
+		var reaction models.MessageReaction
+		if err := rows.Scan(&reaction.MessageID, &reaction.UserID, &reaction.Emoji, &reaction.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan reaction: %w", err)
+		}
+		i := index[reaction.MessageID]
+		messages[i].Reactions = append(messages[i].Reactions, reaction)
+	}
+	return rows.Err()
+}
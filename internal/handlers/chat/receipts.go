@@ -0,0 +1,169 @@
+package chat
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lib/pq"
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// MarkRead marks the other user's messages in a chat as read
+// @Summary Mark chat read
+// @Description Mark the other user's messages in a chat as read, up to and including the given message. The sender is sent a read event over WebSocket unless the caller turned read receipts off with the send_read_receipts preference; the messages count as read for the caller either way.
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param chatID path string true "Chat ID"
+// @Param request body models.MarkReadRequest true "Last message read"
+// @Success 200 {object} models.APIResponse{data=models.ChatReadState} "Chat marked read"
+// @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Chat or message not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /chats/{chatID}/read [post]
+func (h *Handler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	chatID := chi.URLParam(r, "chatID")
+	if chatID == "" {
+		utils.WriteErrorResponse(w, "Chat ID is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MarkReadRequest
+	if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	// The message has to be in a chat the user belongs to
+	var sendReceipts bool
+	err := database.DB.QueryRow(`
+		SELECT COALESCE(p.send_read_receipts, true)
+		FROM messages msg
+		JOIN chats c ON c.id = msg.chat_id
+		JOIN matches m ON m.id = c.match_id
+		LEFT JOIN user_preferences p ON p.user_id = $3
+		WHERE msg.id = $1 AND msg.chat_id = $2
+		  AND (m.user1_id = $3 OR m.user2_id = $3) AND m.is_active = true
+	`, req.MessageID, chatID, user.UserID).Scan(&sendReceipts)
+	if err == sql.ErrNoRows {
+		utils.WriteNotFound(w, "Chat or message not found")
+		return
+	}
+	if err != nil {
+		utils.LogError("Error checking chat access", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	// Everything up to the message counts as read. Without receipts the
+	// sender's view stays unchanged.
+	now := time.Now()
+	rows, err := database.DB.Query(`
+		UPDATE messages
+		SET is_read = true,
+		    delivered_at = COALESCE(delivered_at, $4),
+		    read_at = CASE WHEN $5 THEN $4 ELSE read_at END
+		WHERE chat_id = $1 AND sender_id != $2 AND is_read = false
+		  AND seq <= (SELECT seq FROM messages WHERE id = $3)
+		RETURNING id
+	`, chatID, user.UserID, req.MessageID, now, sendReceipts)
+	if err != nil {
+		utils.LogError("Error marking messages as read", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	var readIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			utils.LogError("Error scanning read message", err)
+			utils.WriteInternalError(w, err)
+			return
+		}
+		readIDs = append(readIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		utils.LogError("Error marking messages as read", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if sendReceipts && len(readIDs) > 0 {
+		BroadcastReceipt(EventRead, chatID, user.UserID, readIDs, now)
+	}
+
+	utils.WriteSuccessResponse(w, "Chat marked read", models.ChatReadState{
+		ChatID:            chatID,
+		LastReadMessageID: req.MessageID,
+		MarkedRead:        len(readIDs),
+		ReceiptsSent:      sendReceipts,
+		ReadAt:            now,
+	})
+}
+
+// markDelivered records that the other user's undelivered messages in a chat
+// reached the recipient, or only the given messages if messageIDs is not
+// empty, and sends the sender a delivered event. It returns the delivery time
+// and the IDs of the messages it marked.
+func (h *Handler) markDelivered(chatID, recipientID string, messageIDs []string) (time.Time, map[string]bool) {
+	now := time.Now()
+	rows, err := database.DB.Query(`
+		UPDATE messages SET delivered_at = $3
+		WHERE chat_id = $1 AND sender_id != $2 AND delivered_at IS NULL
+		  AND (COALESCE(cardinality($4::text[]), 0) = 0 OR id::text = ANY($4::text[]))
+		RETURNING id
+	`, chatID, recipientID, now, pq.Array(messageIDs))
+	if err != nil {
+		utils.LogError("Error marking messages as delivered", err)
+		return now, nil
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	delivered := make(map[string]bool)
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			utils.LogError("Error scanning delivered message", err)
+			continue
+		}
+		delivered[id] = true
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		utils.LogError("Error marking messages as delivered", err)
+	}
+
+	if len(ids) > 0 {
+		BroadcastReceipt(EventDelivered, chatID, recipientID, ids, now)
+	}
+	return now, delivered
+}
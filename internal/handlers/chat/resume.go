@@ -0,0 +1,181 @@
+package chat
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+const (
+	// maxResumeChats is how many chats a single resume frame may list
+	maxResumeChats = 100
+	// resumeReplayLimit is how many missed messages are replayed per chat.
+	// Clients page through the rest with GET /chats/{chatID}/messages?since_seq=.
+	resumeReplayLimit = 50
+)
+
+// resume replays the messages a reconnecting client missed. For every chat in
+// the frame that has messages after the client's last seen sequence number, or
+// earlier messages changed after its last seen change sequence number, a
+// replay frame carries them oldest first along with the chat's last_seq and
+// last_change_seq; a resumed frame follows once all chats were handled.
+func (c *Connection) resume(frame WebSocketMessage) {
+	if len(frame.LastSeqs) > maxResumeChats || len(frame.ChangeSeqs) > maxResumeChats {
+		c.reply(WebSocketMessage{Type: FrameError, Error: fmt.Sprintf("resume may list at most %d chats", maxResumeChats)})
+		return
+	}
+	if database.DB == nil {
+		c.reply(WebSocketMessage{Type: FrameError, Error: "Internal server error"})
+		return
+	}
+
+	for chatID, sinceSeq := range frame.LastSeqs {
+		if _, err := uuid.Parse(chatID); err != nil || sinceSeq < 0 {
+			continue
+		}
+		// Without a change sequence number the client only catches up on
+		// new messages
+		sinceChangeSeq, replayChanges := frame.ChangeSeqs[chatID]
+		if sinceChangeSeq < 0 {
+			continue
+		}
+
+		missed, err := c.handler.messagesSince(c.UserID, chatID, sinceSeq, sinceChangeSeq, replayChanges, resumeReplayLimit)
+		if err != nil {
+			utils.LogError("Error replaying missed messages", err)
+			continue
+		}
+		if missed == nil || (len(missed.messages) == 0 && len(missed.changed) == 0) {
+			continue
+		}
+
+		queued := c.reply(WebSocketMessage{
+			Type:          FrameReplay,
+			ChatID:        chatID,
+			Messages:      missed.messages,
+			Changed:       missed.changed,
+			LastSeq:       missed.lastSeq,
+			LastChangeSeq: missed.lastChangeSeq,
+		})
+		if !queued || len(missed.messages) == 0 {
+			continue
+		}
+
+		// Only the replayed messages have reached this user; later ones are
+		// delivered when the client pages through them. The delivered event
+		// also tells this user when.
+		ids := make([]string, len(missed.messages))
+		for i := range missed.messages {
+			ids[i] = missed.messages[i].ID
+		}
+		c.handler.markDelivered(chatID, c.UserID, ids)
+	}
+
+	c.reply(WebSocketMessage{Type: FrameResumed})
+}
+
+// missedMessages is what a reconnecting client missed in a chat
+type missedMessages struct {
+	messages      []models.Message // Sent after the client's last seen message
+	changed       []models.Message // Seen before, but edited, unsent or reacted to since
+	lastSeq       int64
+	lastChangeSeq int64
+}
+
+// messagesSince returns up to limit messages in a chat after the given
+// sequence number, and if replayChanges is set up to limit earlier messages
+// changed after the given change sequence number, both oldest first. It
+// returns nil if the chat is not one of the user's active chats.
+func (h *Handler) messagesSince(userID, chatID string, sinceSeq, sinceChangeSeq int64, replayChanges bool, limit int) (*missedMessages, error) {
+	var missed missedMessages
+	err := database.DB.QueryRow(`
+		SELECT c.last_seq, c.last_change_seq FROM chats c
+		JOIN matches m ON c.match_id = m.id
+		WHERE c.id = $1 AND (m.user1_id = $2 OR m.user2_id = $2) AND m.is_active = true
+	`, chatID, userID).Scan(&missed.lastSeq, &missed.lastChangeSeq)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check chat access: %w", err)
+	}
+
+	if missed.lastSeq > sinceSeq {
+		missed.messages, err = h.queryMessages(userID, `
+			WHERE chat_id = $1 AND seq > $3
+			ORDER BY seq ASC
+			LIMIT $4
+		`, chatID, userID, sinceSeq, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query missed messages: %w", err)
+		}
+	}
+
+	if replayChanges && missed.lastChangeSeq > sinceChangeSeq {
+		missed.changed, err = h.queryMessages(userID, `
+			WHERE chat_id = $1 AND seq <= $3 AND change_seq > $4
+			ORDER BY change_seq ASC
+			LIMIT $5
+		`, chatID, userID, sinceSeq, sinceChangeSeq, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query changed messages: %w", err)
+		}
+	}
+
+	return &missed, nil
+}
+
+// queryMessages loads the messages matching the condition, which follows the
+// FROM clause, with their reactions and attachments as the viewer sees them.
+// The viewer must be passed as $2.
+func (h *Handler) queryMessages(viewerID, condition string, args ...any) ([]models.Message, error) {
+	rows, err := database.DB.Query(`
+		SELECT id, chat_id, sender_id, seq, change_seq, content, message_type,
+		       CASE WHEN sender_id = $2 THEN read_at IS NOT NULL ELSE is_read END,
+		       delivered_at, read_at, edited_at, deleted_at, created_at,
+		       CASE WHEN sender_id = $2 THEN client_message_id END, gif
+		FROM messages
+	`+condition, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	var messages []models.Message
+	for rows.Next() {
+		var message models.Message
+		var clientMessageID sql.NullString
+		var gifJSON []byte
+		if err := rows.Scan(
+			&message.ID, &message.ChatID, &message.SenderID, &message.Seq, &message.ChangeSeq, &message.Content, &message.MessageType,
+			&message.IsRead, &message.DeliveredAt, &message.ReadAt, &message.EditedAt, &message.DeletedAt,
+			&message.CreatedAt, &clientMessageID, &gifJSON,
+		); err != nil {
+			return nil, err
+		}
+		message.ClientMessageID = clientMessageID.String
+		message.GIF = decodeGIF(gifJSON)
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := attachReactions(messages); err != nil {
+		return nil, err
+	}
+	if err := h.attachAttachments(context.Background(), messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
@@ -1,10 +1,14 @@
 package chat
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
 
@@ -15,6 +19,8 @@ import (
 )
 
 var upgrader = websocket.Upgrader{
+	// Echoed to clients that sent their access token as a subprotocol
+	Subprotocols: []string{middleware.WebSocketTokenProtocol},
 	CheckOrigin: func(r *http.Request) bool {
 		// Allow connections from any origin (configure appropriately for production)
 		return true
@@ -23,9 +29,14 @@ var upgrader = websocket.Upgrader{
 
 // Connection represents a WebSocket connection
 type Connection struct {
-	UserID string
-	Conn   *websocket.Conn
-	Send   chan []byte
+	UserID  string
+	Conn    *websocket.Conn
+	Send    chan []byte
+	handler *Handler // Stores messages sent over the connection
+
+	// ctx is cancelled when the connection closes, ending work done for it
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // Hub maintains active WebSocket connections
@@ -44,12 +55,66 @@ var hub = &Hub{
 	unregister:  make(chan *Connection),
 }
 
+// Receipt events sent to chat members when messages are delivered or read
+const (
+	EventDelivered = "delivered"
+	EventRead      = "read"
+)
+
+// Events sent to chat members when a message is changed after it was sent
+const (
+	EventMessageEdited   = "message_edited"
+	EventMessageDeleted  = "message_deleted"
+	EventReactionAdded   = "reaction_added"
+	EventReactionRemoved = "reaction_removed"
+)
+
+// Frames for messages sent over the WebSocket. Every send_message frame is
+// answered with an ack carrying the stored message's ID, or an error.
+const (
+	FrameSendMessage = "send_message"
+	FrameAck         = "ack"
+	FrameError       = "error"
+)
+
+// Frames for catching up after a reconnect. A resume frame lists the last
+// sequence number and change sequence number the client saw per chat; missed
+// and changed messages come back in a replay frame per chat, followed by
+// resumed.
+const (
+	FrameResume  = "resume"
+	FrameReplay  = "replay"
+	FrameResumed = "resumed"
+)
+
 // WebSocketMessage represents a WebSocket message
 type WebSocketMessage struct {
-	Type    string `json:"type"`
-	ChatID  string `json:"chat_id,omitempty"`
-	Message any    `json:"message,omitempty"`
-	UserID  string `json:"user_id,omitempty"`
+	Type       string     `json:"type"`
+	ChatID     string     `json:"chat_id,omitempty"`
+	MatchID    string     `json:"match_id,omitempty"`
+	Message    any        `json:"message,omitempty"`
+	UserID     string     `json:"user_id,omitempty"`
+	MessageIDs []string   `json:"message_ids,omitempty"` // Messages a receipt covers
+	Timestamp  *time.Time `json:"timestamp,omitempty"`   // When a receipt happened, or a sent message was stored
+
+	// Fields of send_message frames and their replies
+	ClientMessageID string `json:"client_message_id,omitempty"`
+	MessageID       string `json:"message_id,omitempty"`
+	Content         string `json:"content,omitempty"`
+	MessageType     string `json:"message_type,omitempty"`
+	AttachmentID    string `json:"attachment_id,omitempty"`
+	GIFID           string `json:"gif_id,omitempty"`
+	Error           string `json:"error,omitempty"`
+	Emoji           string `json:"emoji,omitempty"`      // Reaction events only
+	ChangeSeq       int64  `json:"change_seq,omitempty"` // Edit, unsend and reaction events only
+
+	// Fields of resume frames and their replies
+	LastSeqs      map[string]int64 `json:"last_seqs,omitempty"`   // Chat ID to the last sequence number seen
+	ChangeSeqs    map[string]int64 `json:"change_seqs,omitempty"` // Chat ID to the last change sequence number seen
+	Messages      []models.Message `json:"messages,omitempty"`
+	Changed       []models.Message `json:"changed,omitempty"`         // Earlier messages changed since the last change seen
+	LastSeq       int64            `json:"last_seq,omitempty"`        // Newest sequence number in the chat
+	LastChangeSeq int64            `json:"last_change_seq,omitempty"` // Newest change sequence number in the chat
 }
 
 func init() {
@@ -123,34 +188,43 @@ func (h *Hub) broadcastToChat(chatID string, message []byte) {
 	h.sendToUser(user2ID, message)
 }
 
-// sendToUser sends a message to a specific user
+// sendToUser sends a message to a specific user. The lock is held while
+// sending so unregister can't close a connection's channel mid-send. A
+// connection too slow to keep up is closed instead of blocking the hub; its
+// read pump then unregisters it and the client resumes after reconnecting.
 func (h *Hub) sendToUser(userID string, message []byte) {
 	h.mutex.RLock()
-	connections, ok := h.connections[userID]
-	h.mutex.RUnlock()
+	defer h.mutex.RUnlock()
 
-	if !ok {
-		return
-	}
-
-	for _, conn := range connections {
+	for _, conn := range h.connections[userID] {
 		select {
 		case conn.Send <- message:
 		default:
-			close(conn.Send)
+			log.Printf("Closing WebSocket of user %s: send buffer full", conn.UserID)
+			if err := conn.Conn.Close(); err != nil {
+				log.Printf("Error closing connection: %v", err)
+			}
 		}
 	}
 }
 
+// isOnline reports whether the user has an open WebSocket connection
+func (h *Hub) isOnline(userID string) bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+	return len(h.connections[userID]) > 0
+}
+
 // HandleWebSocket handles WebSocket connections for real-time chat
 // @Summary WebSocket connection
 // @Description Establish WebSocket connection for real-time chat and notifications
 // @Tags Chat
 // @Accept json
 // @Produce json
-// @Param user_id query string true "User ID for WebSocket connection"
+// @Security BearerAuth
+// @Param Sec-WebSocket-Protocol header string false "access_token, <token> for clients that can't set the Authorization header"
 // @Success 101 {string} string "WebSocket connection established"
-// @Failure 400 {object} models.ErrorResponse "Bad request - missing user_id"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
 // @Router /ws [get]
 func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 	// Get user from context
@@ -167,11 +241,16 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Create connection
+	// Create connection. The request context ends when this handler returns,
+	// so the connection gets its own.
+	ctx, cancel := context.WithCancel(context.Background())
 	connection := &Connection{
-		UserID: user.UserID,
-		Conn:   conn,
-		Send:   make(chan []byte, 256),
+		UserID:  user.UserID,
+		Conn:    conn,
+		Send:    make(chan []byte, 256),
+		handler: h,
+		ctx:     ctx,
+		cancel:  cancel,
 	}
 
 	// Register connection
@@ -185,6 +264,7 @@ func (h *Handler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
 // readPump handles incoming WebSocket messages
 func (c *Connection) readPump() {
 	defer func() {
+		c.cancel()
 		hub.unregister <- c
 		if err := c.Conn.Close(); err != nil {
 			log.Printf("Error closing connection: %v", err)
@@ -215,16 +295,97 @@ func (c *Connection) readPump() {
 			if data, err := json.Marshal(wsMsg); err == nil {
 				hub.broadcast <- data
 			}
+		case FrameSendMessage:
+			c.sendMessage(wsMsg)
+		case FrameResume:
+			c.resume(wsMsg)
 		case "ping":
 			// Send pong response
-			pong := WebSocketMessage{Type: "pong"}
-			if data, err := json.Marshal(pong); err == nil {
-				c.Send <- data
-			}
+			c.reply(WebSocketMessage{Type: "pong"})
 		}
 	}
 }
 
+// sendMessage stores a message sent over the connection and acks it. The
+// client message ID is required so a frame resent after reconnecting is
+// acked with the message stored the first time instead of sending it twice.
+func (c *Connection) sendMessage(frame WebSocketMessage) {
+	if frame.ClientMessageID == "" {
+		c.reply(WebSocketMessage{Type: FrameError, ChatID: frame.ChatID, Error: "client_message_id is required"})
+		return
+	}
+	reject := func(reason string) {
+		c.reply(WebSocketMessage{Type: FrameError, ChatID: frame.ChatID, ClientMessageID: frame.ClientMessageID, Error: reason})
+	}
+
+	req := models.MessageRequest{
+		Content:         frame.Content,
+		MessageType:     frame.MessageType,
+		ClientMessageID: frame.ClientMessageID,
+		AttachmentID:    frame.AttachmentID,
+		GIFID:           frame.GIFID,
+	}
+	if frame.ChatID == "" {
+		reject("chat_id is required")
+		return
+	}
+	if err := utils.ValidateStruct(req); err != nil {
+		reject(err.Error())
+		return
+	}
+
+	if c.handler.rateLimits != nil {
+		if allowed, retryAfter := c.handler.rateLimits.AllowUser("message_send", c.UserID); !allowed {
+			reject(fmt.Sprintf("Too many requests, retry after %d seconds", int(math.Ceil(retryAfter.Seconds()))))
+			return
+		}
+	}
+
+	if database.DB == nil {
+		reject("Internal server error")
+		return
+	}
+
+	message, _, err := c.handler.createMessage(c.ctx, c.UserID, frame.ChatID, req)
+	if isRejectedMessage(err) {
+		reject(err.Error())
+		return
+	}
+	if err != nil {
+		utils.LogError("Error sending message over WebSocket", err)
+		reject("Internal server error")
+		return
+	}
+	if message == nil {
+		reject("Chat not found or access denied")
+		return
+	}
+
+	c.reply(WebSocketMessage{
+		Type:            FrameAck,
+		ChatID:          message.ChatID,
+		ClientMessageID: message.ClientMessageID,
+		MessageID:       message.ID,
+		Timestamp:       &message.CreatedAt,
+	})
+}
+
+// reply sends a frame to this connection only. It reports whether the frame
+// was queued; it is dropped if the send buffer is full.
+func (c *Connection) reply(frame WebSocketMessage) bool {
+	data, err := json.Marshal(frame)
+	if err != nil {
+		return false
+	}
+	select {
+	case c.Send <- data:
+		return true
+	default:
+		log.Printf("Dropping WebSocket reply to user %s: send buffer full", c.UserID)
+		return false
+	}
+}
+
 // writePump handles outgoing WebSocket messages
 func (c *Connection) writePump() {
 	defer func() {
@@ -253,3 +414,49 @@ func BroadcastMessage(chatID string, message *models.Message) {
 		hub.broadcast <- data
 	}
 }
+
+// NotifyUnmatched tells the clients of both users that a match was removed so
+// they drop the conversation. The chat is no longer active, so the event is
+// sent to the users directly instead of through the chat.
+func NotifyUnmatched(matchID, chatID, unmatchedBy string, userIDs ...string) {
+	wsMsg := WebSocketMessage{
+		Type:    "unmatched",
+		ChatID:  chatID,
+		MatchID: matchID,
+		UserID:  unmatchedBy,
+	}
+
+	data, err := json.Marshal(wsMsg)
+	if err != nil {
+		return
+	}
+	for _, userID := range userIDs {
+		hub.sendToUser(userID, data)
+	}
+}
+
+// BroadcastReceipt tells the members of a chat that messages were delivered to
+// or read by the given user. It goes through the hub like new messages, so a
+// receipt never overtakes the message it is about.
+func BroadcastReceipt(event, chatID, userID string, messageIDs []string, at time.Time) {
+	wsMsg := WebSocketMessage{
+		Type:       event,
+		ChatID:     chatID,
+		UserID:     userID,
+		MessageIDs: messageIDs,
+		Timestamp:  &at,
+	}
+
+	if data, err := json.Marshal(wsMsg); err == nil {
+		hub.broadcast <- data
+	}
+}
+
+// BroadcastMessageEvent tells the members of a chat that a message was
+// edited, unsent or reacted to. Edits carry the updated message; the other
+// events identify it by MessageID.
+func BroadcastMessageEvent(wsMsg WebSocketMessage) {
+	if data, err := json.Marshal(wsMsg); err == nil {
+		hub.broadcast <- data
+	}
+}
@@ -2,6 +2,7 @@ package image
 
 import (
 	"matching-api/internal/handlers/shared"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/services"
 )
 
@@ -12,9 +13,9 @@ type Handler struct {
 }
 
 // NewHandler creates a new image handler
-func NewHandler(s3Service *services.S3Service, redisService *services.RedisService) *Handler {
+func NewHandler(s3Service *services.S3Service, redisService *services.RedisService, cacheStore cache.Cache) *Handler {
 	return &Handler{
-		BaseHandler: shared.NewBaseHandler(redisService),
+		BaseHandler: shared.NewBaseHandler(redisService, cacheStore),
 		S3Service:   s3Service,
 	}
 }
@@ -1,28 +1,24 @@
 package match
 
 import (
+	"log"
 	"math"
 	"sort"
 	"time"
 
 	"matching-api/internal/models"
+	"matching-api/pkg/geo"
 )
 
 // findPotentialMatches finds potential matches based on user preferences and compatibility
 func (h *Handler) findPotentialMatches(user *models.User, prefs *models.UserPrefs, limit int) []models.User {
-	// Query the database with complex filters based on user preferences
-	// TODO: Implement sophisticated database query with preference filtering
-	// This should include age range, distance, gender preferences, etc.
+	// Query candidates within range; the database applies the coarse filters,
+	// including skipping profiles the user already swiped on
 	potentialUsers := h.getPotentialUsers(user, prefs)
 
 	var matches []models.User
 
 	for _, candidate := range potentialUsers {
-		// Skip if already swiped
-		if h.hasAlreadySwiped(user.ID, candidate.ID) {
-			continue
-		}
-
 		// Apply preference filters
 		if !h.matchesPreferences(candidate, *prefs, *user) {
 			continue
@@ -31,13 +27,22 @@ func (h *Handler) findPotentialMatches(user *models.User, prefs *models.UserPref
 		matches = append(matches, candidate)
 	}
 
-	// Sort by compatibility score
-	sort.Slice(matches, func(i, j int) bool {
-		scoreI := h.calculateCompatibilityScore(*user, matches[i])
-		scoreJ := h.calculateCompatibilityScore(*user, matches[j])
-		return scoreI > scoreJ
+	// Sort by compatibility score, with boosted profiles ranked higher
+	boosts := h.activeBoosts(matches)
+	scores := make(map[string]float64, len(matches))
+	for _, candidate := range matches {
+		scores[candidate.ID] = h.calculateCompatibilityScore(*user, candidate)
+		if _, boosted := boosts[candidate.ID]; boosted {
+			scores[candidate.ID] += boostScoreBonus
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return scores[matches[i].ID] > scores[matches[j].ID]
 	})
 
+	// Profiles returned by a rewind go back to the top of the deck
+	matches = h.applyDeckPriority(user.ID, matches)
+
 	// Limit results
 	if len(matches) > limit {
 		matches = matches[:limit]
@@ -46,6 +51,59 @@ func (h *Handler) findPotentialMatches(user *models.User, prefs *models.UserPref
 	return matches
 }
 
+// boostScoreBonus is added to the compatibility score of boosted candidates.
+// It outweighs most of the score's other signals, so boosted profiles rise to
+// the top of the deck without overriding the preference filters.
+const boostScoreBonus = 40.0
+
+// activeBoosts returns the running boost ID of each boosted candidate
+func (h *Handler) activeBoosts(candidates []models.User) map[string]string {
+	if h.Boosts == nil || len(candidates) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		ids[i] = candidate.ID
+	}
+
+	boosts, err := h.Boosts.ActiveBoosts(ids)
+	if err != nil {
+		log.Printf("Warning: Failed to load active boosts: %v", err)
+		return nil
+	}
+	return boosts
+}
+
+// applyDeckPriority moves profiles pinned to the top of the user's deck to the
+// front, most recently pinned first
+func (h *Handler) applyDeckPriority(userID string, candidates []models.User) []models.User {
+	if h.RedisService == nil || len(candidates) == 0 {
+		return candidates
+	}
+
+	pinned, err := h.RedisService.GetDeckPriority(userID)
+	if err != nil || len(pinned) == 0 {
+		return candidates
+	}
+
+	rank := make(map[string]int, len(pinned))
+	for i, id := range pinned {
+		rank[id] = i
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		rankI, pinnedI := rank[candidates[i].ID]
+		rankJ, pinnedJ := rank[candidates[j].ID]
+		if pinnedI != pinnedJ {
+			return pinnedI
+		}
+		return pinnedI && rankI < rankJ
+	})
+
+	return candidates
+}
+
 // matchesPreferences checks if a candidate matches user preferences
 func (h *Handler) matchesPreferences(candidate models.User, prefs models.UserPrefs, user models.User) bool {
 	// Age filter
@@ -137,18 +195,5 @@ func (h *Handler) calculateCompatibilityScore(user1, user2 models.User) float64
 
 // calculateDistance calculates distance between two locations using Haversine formula
 func (h *Handler) calculateDistance(loc1, loc2 models.Location) float64 {
-	// Haversine formula for distance calculation
-	const R = 6371 // Earth's radius in kilometers
-
-	lat1Rad := loc1.Latitude * math.Pi / 180
-	lat2Rad := loc2.Latitude * math.Pi / 180
-	deltaLat := (loc2.Latitude - loc1.Latitude) * math.Pi / 180
-	deltaLng := (loc2.Longitude - loc1.Longitude) * math.Pi / 180
-
-	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
-		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
-			math.Sin(deltaLng/2)*math.Sin(deltaLng/2)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-
-	return R * c
-}
\ No newline at end of file
+	return geo.DistanceKm(loc1.Latitude, loc1.Longitude, loc2.Latitude, loc2.Longitude)
+}
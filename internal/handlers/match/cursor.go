@@ -0,0 +1,27 @@
+package match
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// encodeCursor joins the keyset values of the last row of a page into the
+// opaque cursor string handed to clients
+func encodeCursor(values ...string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strings.Join(values, "|")))
+}
+
+// decodeCursor splits a cursor produced by encodeCursor into its values
+func decodeCursor(cursor string, count int) ([]string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	values := strings.Split(string(raw), "|")
+	if len(values) != count {
+		return nil, errors.New("malformed cursor")
+	}
+	return values, nil
+}
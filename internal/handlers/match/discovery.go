@@ -1,13 +1,14 @@
 package match
 
 import (
-	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"matching-api/internal/middleware"
 	"matching-api/internal/models"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/utils"
 )
 
@@ -57,25 +58,30 @@ func (h *Handler) GetPotentialMatches(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Try to get potential matches from cache first
-	cacheKey := fmt.Sprintf("potential_matches_%s_%d", userClaims.UserID, limit)
+	cacheKey := cache.PotentialMatchesKey(userClaims.UserID, strconv.Itoa(limit))
 	var potentialMatches []models.User
-	
-	if h.RedisService != nil {
-		if err := h.RedisService.Get(cacheKey, &potentialMatches); err == nil {
-			log.Printf("Potential matches served from cache for user: %s", userClaims.UserID)
-		} else {
-			// Cache miss, compute potential matches
-			potentialMatches = h.findPotentialMatches(user, preferences, limit)
-			
-			// Cache the results for 5 minutes (potential matches change more frequently)
-			if err := h.RedisService.Set(cacheKey, potentialMatches, 5*time.Minute); err != nil {
-				log.Printf("Warning: Failed to cache potential matches: %v", err)
-			}
-		}
+
+	if err := h.Cache.Get(cacheKey, &potentialMatches); err == nil {
+		log.Printf("Potential matches served from cache for user: %s", userClaims.UserID)
 	} else {
-		// Fallback when Redis is not available
-		potentialMatches = h.findPotentialMatches(user, preferences, limit)
+		// Cache miss, compute potential matches
+		potentialMatches = publicProfiles(h.findPotentialMatches(user, preferences, limit), user.Location)
+
+		// Cache the results for 5 minutes (potential matches change more frequently)
+		if err := h.Cache.Set(cacheKey, potentialMatches, 5*time.Minute, cache.PotentialMatchesTag(userClaims.UserID)); err != nil {
+			log.Printf("Warning: Failed to cache potential matches: %v", err)
+		}
 	}
 
 	utils.WriteSuccessResponse(w, "Potential matches found", potentialMatches)
-}
\ No newline at end of file
+}
+
+// publicProfiles converts candidates to public profiles as seen by the viewer,
+// replacing coordinates with distance buckets
+func publicProfiles(users []models.User, viewer *models.Location) []models.User {
+	profiles := make([]models.User, 0, len(users))
+	for i := range users {
+		profiles = append(profiles, *users[i].PublicFor(viewer))
+	}
+	return profiles
+}
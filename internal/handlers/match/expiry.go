@@ -0,0 +1,69 @@
+package match
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/pkg/utils"
+)
+
+// ExtendMatch gives a match without messages more time before it expires
+// @Summary Extend a match
+// @Description Push back the expiry of a match nobody has written in yet. Each match can be extended once; the reminder is sent again a day before the new expiry.
+// @Tags Matching
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param matchID path string true "Match ID"
+// @Success 200 {object} models.APIResponse{data=models.Match} "Match extended"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Match not found"
+// @Failure 409 {object} models.ErrorResponse "Match does not expire or was already extended"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /matches/{matchID}/extend [post]
+func (h *Handler) ExtendMatch(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	matchID := chi.URLParam(r, "matchID")
+	if matchID == "" {
+		utils.WriteErrorResponse(w, "Match ID is required", http.StatusBadRequest)
+		return
+	}
+	if _, err := uuid.Parse(matchID); err != nil {
+		utils.WriteNotFound(w, "Match not found")
+		return
+	}
+
+	if database.DB == nil || h.Expiry == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	match, extended, err := h.Expiry.Extend(userClaims.UserID, matchID)
+	if err != nil {
+		utils.LogError("Failed to extend match", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if match == nil {
+		utils.WriteNotFound(w, "Match not found")
+		return
+	}
+	if !extended {
+		if match.ExpiresAt == nil {
+			utils.WriteErrorResponse(w, "Match does not expire", http.StatusConflict)
+			return
+		}
+		utils.WriteErrorResponse(w, "Match was already extended", http.StatusConflict)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Match extended", match)
+}
@@ -1,18 +1,42 @@
 package match
 
 import (
+	"os"
+	"strconv"
+	"time"
+
 	"matching-api/internal/handlers/shared"
+	internalServices "matching-api/internal/services"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/services"
 )
 
 // Handler handles matching-related requests
 type Handler struct {
 	shared.BaseHandler
+	Quotas       *internalServices.QuotaService
+	Boosts       *internalServices.BoostService
+	Expiry       *internalServices.MatchExpiryService
+	Analytics    *internalServices.AnalyticsService
+	RewindWindow time.Duration // How long after a swipe it can still be undone
 }
 
+// DefaultRewindWindow is how long a swipe can be undone unless REWIND_WINDOW_MINUTES is set
+const DefaultRewindWindow = 5 * time.Minute
+
 // NewHandler creates a new match handler
-func NewHandler(redisService *services.RedisService) *Handler {
+func NewHandler(redisService *services.RedisService, cacheStore cache.Cache, quotas *internalServices.QuotaService, boosts *internalServices.BoostService, expiry *internalServices.MatchExpiryService) *Handler {
+	rewindWindow := DefaultRewindWindow
+	if minutes, err := strconv.Atoi(os.Getenv("REWIND_WINDOW_MINUTES")); err == nil && minutes > 0 {
+		rewindWindow = time.Duration(minutes) * time.Minute
+	}
+
 	return &Handler{
-		BaseHandler: shared.NewBaseHandler(redisService),
+		BaseHandler:  shared.NewBaseHandler(redisService, cacheStore),
+		Quotas:       quotas,
+		Boosts:       boosts,
+		Expiry:       expiry,
+		Analytics:    internalServices.NewAnalyticsService(),
+		RewindWindow: rewindWindow,
 	}
 }
\ No newline at end of file
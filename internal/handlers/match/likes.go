@@ -0,0 +1,313 @@
+package match
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// Shared filter for likes on the caller ($1) that are still waiting for an
+// answer. Likes from inactive or suspended users are hidden.
+const pendingLikesFilter = `
+	s.target_id = $1
+	AND s.action IN ('right', 'super')
+	AND u.is_active = true
+	AND (u.suspended_at IS NULL OR u.suspended_until <= NOW())
+	AND NOT EXISTS (SELECT 1 FROM swipes r WHERE r.user_id = $1 AND r.target_id = s.user_id)
+`
+
+// likesCursor marks the last like of a page. Likes are ordered by rank
+// (super likes first), then newest first.
+type likesCursor struct {
+	Rank      int
+	CreatedAt time.Time
+	ID        string
+}
+
+// encode returns the opaque cursor string handed to clients
+func (c likesCursor) encode() string {
+	return encodeCursor(strconv.Itoa(c.Rank), strconv.FormatInt(c.CreatedAt.UnixNano(), 10), c.ID)
+}
+
+// decodeLikesCursor parses a cursor produced by likesCursor.encode
+func decodeLikesCursor(value string) (*likesCursor, error) {
+	parts, err := decodeCursor(value, 3)
+	if err != nil {
+		return nil, err
+	}
+	rank, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(parts[2]); err != nil {
+		return nil, err
+	}
+
+	return &likesCursor{Rank: rank, CreatedAt: time.Unix(0, nanos), ID: parts[2]}, nil
+}
+
+// GetLikesReceived lists users who liked the current user and are still waiting for an answer
+// @Summary Get likes received
+// @Description Get pending likes and super likes on the current user that have not been answered yet. Super likes come first, then the newest likes. Users whose plan includes seeing who likes them get full profiles; everyone else gets blurred previews without the liker's profile. Pass next_cursor from the previous page as cursor to continue.
+// @Tags Matching
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of likes per page (max 50)" default(20)
+// @Param cursor query string false "Cursor from the previous page"
+// @Success 200 {object} models.APIResponse{data=models.ReceivedLikesPage} "Likes retrieved successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid cursor"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /matches/likes-received [get]
+func (h *Handler) GetLikesReceived(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	limit, err := utils.GetQueryParamInt(r, "limit", 20)
+	if err != nil {
+		utils.WriteErrorResponse(w, "Invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	var cursor *likesCursor
+	if value := r.URL.Query().Get("cursor"); value != "" {
+		if cursor, err = decodeLikesCursor(value); err != nil {
+			utils.WriteErrorResponse(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	canSee := h.canSeeWhoLikesYou(userClaims.UserID)
+
+	var total int
+	err = database.DB.QueryRow(`
+		SELECT COUNT(*) FROM swipes s JOIN users u ON u.id = s.user_id
+		WHERE `+pendingLikesFilter, userClaims.UserID).Scan(&total)
+	if err != nil {
+		utils.LogError("Failed to count received likes", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	likes, next, err := h.getReceivedLikes(userClaims.UserID, cursor, limit, canSee)
+	if err != nil {
+		utils.LogError("Failed to get received likes", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	page := models.ReceivedLikesPage{
+		Likes:  likes,
+		Total:  total,
+		CanSee: canSee,
+	}
+	if next != nil {
+		page.NextCursor = next.encode()
+	}
+
+	utils.WriteSuccessResponse(w, "Likes retrieved successfully", page)
+}
+
+// LikeBack likes a user back from the likes inbox, creating a match
+// @Summary Like back
+// @Description Like back a user from the likes inbox. Since the other user already liked the current user this creates a match. Requires a plan that includes seeing who likes you; the like counts against the daily allowance.
+// @Tags Matching
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param likeID path string true "ID of the received like"
+// @Success 200 {object} models.APIResponse{data=object{swipe=models.Swipe,is_match=bool,match_id=string}} "Liked back successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - already answered"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 403 {object} models.ErrorResponse "Plan does not include seeing who likes you"
+// @Failure 404 {object} models.ErrorResponse "Like not found"
+// @Failure 429 {object} models.QuotaExceededResponse "Daily like allowance used up"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /matches/likes-received/{likeID}/like-back [post]
+func (h *Handler) LikeBack(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	likeID := chi.URLParam(r, "likeID")
+	if _, err := uuid.Parse(likeID); err != nil {
+		utils.WriteNotFound(w, "Like not found")
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	if !h.canSeeWhoLikesYou(userClaims.UserID) {
+		utils.WriteForbidden(w, "Upgrade your plan to see who likes you")
+		return
+	}
+
+	var likerID string
+	err := database.DB.QueryRow(`
+		SELECT s.user_id FROM swipes s JOIN users u ON u.id = s.user_id
+		WHERE s.id = $2 AND `+pendingLikesFilter, userClaims.UserID, likeID).Scan(&likerID)
+	if err == sql.ErrNoRows {
+		utils.WriteNotFound(w, "Like not found")
+		return
+	}
+	if err != nil {
+		utils.LogError("Failed to load received like", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	response, ok := h.performSwipe(w, userClaims.UserID, likerID, models.SwipeRight)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Liked back successfully", response)
+}
+
+// canSeeWhoLikesYou reports whether the user's plan reveals likers' profiles
+func (h *Handler) canSeeWhoLikesYou(userID string) bool {
+	if h.Quotas == nil {
+		return false
+	}
+	entitlements, err := h.Quotas.Entitlements(userID)
+	if err != nil {
+		utils.LogError("Failed to load entitlements", err)
+		return false
+	}
+	return entitlements.SeeWhoLikesYou
+}
+
+// getReceivedLikes loads one page of pending likes after the cursor. It
+// returns the cursor of the next page, or nil on the last page.
+func (h *Handler) getReceivedLikes(userID string, cursor *likesCursor, limit int, canSee bool) ([]models.ReceivedLike, *likesCursor, error) {
+	args := []interface{}{userID}
+	keyset := ""
+	if cursor != nil {
+		// Rank ascends while time and ID descend, so one row comparison is not enough
+		keyset = `AND (k.rank > $2 OR (k.rank = $2 AND (s.created_at, s.id) < ($3, $4::uuid)))`
+		args = append(args, cursor.Rank, cursor.CreatedAt, cursor.ID)
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+		SELECT s.id, s.action, s.created_at, k.rank,
+		       u.id, u.first_name, u.age, u.bio, u.gender, u.latitude, u.longitude, u.last_seen,
+		       COALESCE(p.hide_distance, false), COALESCE(p.hide_age, false), ph.url
+		FROM swipes s
+		JOIN users u ON u.id = s.user_id
+		CROSS JOIN LATERAL (SELECT CASE WHEN s.action = 'super' THEN 0 ELSE 1 END AS rank) k
+		LEFT JOIN user_preferences p ON p.user_id = u.id
+		LEFT JOIN LATERAL (
+			SELECT url FROM images WHERE user_id = u.id AND is_active = true ORDER BY position LIMIT 1
+		) ph ON true
+		WHERE %s %s
+		ORDER BY k.rank, s.created_at DESC, s.id DESC
+		LIMIT $%d
+	`, pendingLikesFilter, keyset, len(args))
+
+	viewer := h.loadViewerLocation(userID)
+
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	likes := []models.ReceivedLike{}
+	var last likesCursor
+	var hasMore bool
+	for rows.Next() {
+		if len(likes) == limit {
+			hasMore = true
+			break
+		}
+
+		var like models.ReceivedLike
+		var liker models.User
+		var prefs models.UserPrefs
+		var rank int
+		var bio, photoURL sql.NullString
+		var lat, lng sql.NullFloat64
+		if err := rows.Scan(&like.ID, &like.Action, &like.CreatedAt, &rank,
+			&liker.ID, &liker.FirstName, &liker.Age, &bio, &liker.Gender, &lat, &lng, &liker.LastSeen,
+			&prefs.HideDistance, &prefs.HideAge, &photoURL); err != nil {
+			return nil, nil, err
+		}
+
+		like.IsSuperLike = like.Action == models.SuperLike
+		like.Blurred = !canSee
+		if canSee {
+			liker.Bio = bio.String
+			liker.IsActive = true
+			liker.Preferences = &prefs // Only the privacy flags, used by PublicFor
+			if lat.Valid && lng.Valid {
+				liker.Location = &models.Location{Latitude: lat.Float64, Longitude: lng.Float64}
+			}
+			if photoURL.Valid {
+				liker.Photos = []models.Photo{{UserID: liker.ID, URL: photoURL.String, Position: 1}}
+			}
+			like.User = liker.PublicFor(viewer)
+		}
+
+		likes = append(likes, like)
+		last = likesCursor{Rank: rank, CreatedAt: like.CreatedAt, ID: like.ID}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if !hasMore {
+		return likes, nil, nil
+	}
+	return likes, &last, nil
+}
+
+// loadViewerLocation returns the user's saved coordinates, or nil if unknown
+func (h *Handler) loadViewerLocation(userID string) *models.Location {
+	var lat, lng sql.NullFloat64
+	err := database.DB.QueryRow(`SELECT latitude, longitude FROM users WHERE id = $1`, userID).Scan(&lat, &lng)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			utils.LogError("Failed to load viewer location", err)
+		}
+		return nil
+	}
+	if !lat.Valid || !lng.Valid {
+		return nil
+	}
+	return &models.Location{Latitude: lat.Float64, Longitude: lng.Float64}
+}
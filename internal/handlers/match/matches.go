@@ -7,22 +7,27 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"matching-api/internal/database"
+	"matching-api/internal/handlers/chat"
 	"matching-api/internal/middleware"
 	"matching-api/internal/models"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/utils"
 )
 
 // GetMatches retrieves user's current matches
 // @Summary Get user matches
-// @Description Retrieve user's current matches with pagination
+// @Description Retrieve user's current matches, most recently active first (last message, or match time for new matches). Pass next_cursor from the previous page as cursor to continue. Filter by new matches without messages or by conversations. When match expiry is enabled, matches nobody has written in yet include expires_at.
 // @Tags Matching
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param page query int false "Page number" default(1)
 // @Param limit query int false "Number of matches per page (max 100)" default(20)
-// @Success 200 {object} models.APIResponse{data=object{matches=[]models.Match,page=int,limit=int,total=int}} "Matches retrieved successfully"
-// @Failure 400 {object} models.ErrorResponse "Bad request - invalid pagination"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param filter query string false "Which matches to list" Enums(all, new, conversations) default(all)
+// @Success 200 {object} models.APIResponse{data=models.MatchesPage} "Matches retrieved successfully"
+// @Failure 400 {object} models.ErrorResponse "Bad request - invalid pagination or filter"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /matches [get]
@@ -35,65 +40,70 @@ func (h *Handler) GetMatches(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get pagination parameters
-	page, err := utils.GetQueryParamInt(r, "page", 1)
+	limit, err := utils.GetQueryParamInt(r, "limit", 20)
 	if err != nil {
 		utils.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
 
-	limit, err := utils.GetQueryParamInt(r, "limit", 20)
-	if err != nil {
-		utils.WriteErrorResponse(w, err.Error(), http.StatusBadRequest)
-		return
+	var cursor *matchesCursor
+	cursorParam := r.URL.Query().Get("cursor")
+	if cursorParam != "" {
+		if cursor, err = decodeMatchesCursor(cursorParam); err != nil {
+			utils.WriteErrorResponse(w, "Invalid cursor", http.StatusBadRequest)
+			return
+		}
 	}
 
-	// Validate pagination
-	if page < 1 {
-		page = 1
+	filter := models.MatchFilter(r.URL.Query().Get("filter"))
+	if filter == "" {
+		filter = models.MatchFilterAll
 	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	if _, ok := matchFilterConditions[filter]; !ok {
+		utils.WriteErrorResponse(w, "Invalid filter, must be one of all, new, conversations", http.StatusBadRequest)
+		return
 	}
 
-	// Try to get matches from Redis cache first
-	cacheKey := fmt.Sprintf("matches_page_%s_%d_%d", userClaims.UserID, page, limit)
-	var matches []models.Match
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
 
-	if h.RedisService != nil {
-		if err := h.RedisService.Get(cacheKey, &matches); err == nil {
-			log.Printf("Matches served from cache for user: %s", userClaims.UserID)
-		} else {
-			// Cache miss, get from database
-			matches = h.getUserMatches(userClaims.UserID, page, limit)
+	// Pages are cached until the user's matches change
+	cachePage := fmt.Sprintf("%s:%d:%s", filter, limit, cursorParam)
+	cacheKey := cache.MatchesKey(userClaims.UserID, cachePage)
+	var cached models.MatchesPage
+	if err := h.Cache.Get(cacheKey, &cached); err == nil {
+		utils.WriteSuccessResponse(w, "Matches retrieved successfully", cached)
+		return
+	}
 
-			// Cache the results for 10 minutes
-			if err := h.RedisService.Set(cacheKey, matches, 10*time.Minute); err != nil {
-				log.Printf("Warning: Failed to cache matches: %v", err)
-			}
-		}
-	} else {
-		// Fallback when Redis is not available
-		matches = h.getUserMatches(userClaims.UserID, page, limit)
+	page, err := h.getUserMatches(userClaims.UserID, filter, cursor, limit)
+	if err != nil {
+		utils.LogError("Failed to get matches", err)
+		utils.WriteInternalError(w, err)
+		return
 	}
 
-	response := map[string]interface{}{
-		"matches": matches,
-		"page":    page,
-		"limit":   limit,
-		"total":   len(matches), // TODO: Get actual total count from database
+	if err := h.Cache.Set(cacheKey, page, 10*time.Minute, cache.MatchesTag(userClaims.UserID)); err != nil {
+		log.Printf("Warning: Failed to cache matches: %v", err)
 	}
 
-	utils.WriteSuccessResponse(w, "Matches retrieved successfully", response)
+	utils.WriteSuccessResponse(w, "Matches retrieved successfully", page)
 }
 
 // UnMatch removes a match between users
 // @Summary Remove a match
-// @Description Remove/unmatch with another user
+// @Description Unmatch with another user. The match is deactivated, its chat is archived so no more messages can be sent, and both users' clients receive an unmatched WebSocket event. An optional reason is kept for moderation.
 // @Tags Matching
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param matchID path string true "Match ID"
+// @Param request body models.UnmatchRequest false "Optional unmatch reason"
 // @Success 200 {object} models.APIResponse "Match removed successfully"
 // @Failure 400 {object} models.ErrorResponse "Bad request - invalid match ID"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
@@ -113,14 +123,58 @@ func (h *Handler) UnMatch(w http.ResponseWriter, r *http.Request) {
 		utils.WriteErrorResponse(w, "Match ID is required", http.StatusBadRequest)
 		return
 	}
+	if _, err := uuid.Parse(matchID); err != nil {
+		utils.WriteErrorResponse(w, "Invalid match ID", http.StatusBadRequest)
+		return
+	}
+
+	// The reason is optional, so an empty body is fine
+	var req models.UnmatchRequest
+	if r.ContentLength != 0 {
+		if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+			utils.WriteValidationError(w, err)
+			return
+		}
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
 
 	// Verify user is part of this match and deactivate it
-	if !h.isUserInMatch(userClaims.UserID, matchID) {
+	inMatch, err := h.isUserInMatch(userClaims.UserID, matchID)
+	if err != nil {
+		utils.LogError("Failed to check match ownership", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if !inMatch {
 		utils.WriteForbidden(w, "You are not part of this match")
 		return
 	}
 
-	// TODO: Deactivate match in database
+	unmatched, err := h.deactivateMatch(matchID, userClaims.UserID, req.Reason)
+	if err != nil {
+		utils.LogError("Failed to deactivate match", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if unmatched == nil {
+		// The other user unmatched first
+		utils.WriteSuccessResponse(w, "Match removed successfully", nil)
+		return
+	}
+
+	otherUserID := unmatched.otherUserID(userClaims.UserID)
+	h.invalidateMatchCaches(userClaims.UserID, otherUserID)
+
+	// Both users, so the unmatching user's other devices drop the chat too
+	chat.NotifyUnmatched(matchID, unmatched.chatID, userClaims.UserID, userClaims.UserID, otherUserID)
+
+	if err := h.Analytics.TrackUnmatch(userClaims.UserID, otherUserID, matchID, req.Reason, r); err != nil {
+		utils.LogError("Failed to track unmatch", err)
+	}
 
 	utils.WriteSuccessResponse(w, "Match removed successfully", nil)
 }
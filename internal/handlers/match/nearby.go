@@ -0,0 +1,118 @@
+package match
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+	"matching-api/pkg/geo"
+	"matching-api/pkg/utils"
+)
+
+// maxNearbyCandidates caps how many rows a radius query returns before
+// preference filtering and scoring happen in Go
+const maxNearbyCandidates = 500
+
+// queryNearbyUsers returns active candidates within the user's max distance.
+// The radius is applied in SQL: with PostGIS through the GiST-indexed geography
+// column, otherwise through geohash prefixes plus a bounding box. Geohash
+// cells cover a square, so matchesPreferences still checks the exact distance.
+func (h *Handler) queryNearbyUsers(user *models.User, prefs *models.UserPrefs) ([]models.User, error) {
+	radiusKm := float64(prefs.MaxDistance)
+	lat, lng := user.Location.Latitude, user.Location.Longitude
+
+	// Shared filters; the radius condition is appended below
+	conditions := []string{
+		"u.id <> $1",
+		"u.is_active = true",
+		"(u.suspended_at IS NULL OR u.suspended_until <= NOW())",
+		"u.age BETWEEN $2 AND $3",
+		"NOT EXISTS (SELECT 1 FROM swipes s WHERE s.user_id = $1 AND s.target_id = u.id)",
+	}
+	args := []interface{}{user.ID, prefs.AgeMin, prefs.AgeMax}
+	argIndex := 4
+
+	if len(prefs.InterestedIn) > 0 {
+		conditions = append(conditions, fmt.Sprintf("u.gender = ANY($%d)", argIndex))
+		args = append(args, pq.Array(prefs.InterestedIn))
+		argIndex++
+	}
+
+	if database.PostGISEnabled() {
+		conditions = append(conditions, fmt.Sprintf(
+			"ST_DWithin(u.geog, ST_SetSRID(ST_MakePoint($%d, $%d), 4326)::geography, $%d)",
+			argIndex, argIndex+1, argIndex+2))
+		args = append(args, lng, lat, radiusKm*1000)
+		argIndex += 3
+	} else {
+		patterns := geo.CoveringCells(lat, lng, radiusKm)
+		for i := range patterns {
+			patterns[i] += "%"
+		}
+		conditions = append(conditions, fmt.Sprintf("u.geohash LIKE ANY($%d)", argIndex))
+		args = append(args, pq.Array(patterns))
+		argIndex++
+
+		box := geo.Bounds(lat, lng, radiusKm)
+		conditions = append(conditions, fmt.Sprintf("u.latitude BETWEEN $%d AND $%d", argIndex, argIndex+1))
+		args = append(args, box.MinLat, box.MaxLat)
+		argIndex += 2
+		if box.MinLng <= box.MaxLng {
+			conditions = append(conditions, fmt.Sprintf("u.longitude BETWEEN $%d AND $%d", argIndex, argIndex+1))
+		} else {
+			// The box crosses the antimeridian
+			conditions = append(conditions, fmt.Sprintf("(u.longitude >= $%d OR u.longitude <= $%d)", argIndex, argIndex+1))
+		}
+		args = append(args, box.MinLng, box.MaxLng)
+		argIndex += 2
+	}
+
+	query := fmt.Sprintf(`
+		SELECT u.id, u.first_name, u.age, u.bio, u.gender,
+		       u.latitude, u.longitude, u.city, u.state, u.country, u.last_seen,
+		       COALESCE(p.hide_distance, false), COALESCE(p.hide_age, false)
+		FROM users u
+		LEFT JOIN user_preferences p ON p.user_id = u.id
+		WHERE %s
+		ORDER BY u.last_seen DESC NULLS LAST
+		LIMIT $%d
+	`, strings.Join(conditions, " AND "), argIndex)
+	args = append(args, maxNearbyCandidates)
+
+	rows, err := database.DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	var candidates []models.User
+	for rows.Next() {
+		var candidate models.User
+		var bio, city, state, country sql.NullString
+		var location models.Location
+		var prefs models.UserPrefs
+		if err := rows.Scan(&candidate.ID, &candidate.FirstName, &candidate.Age, &bio, &candidate.Gender,
+			&location.Latitude, &location.Longitude, &city, &state, &country, &candidate.LastSeen,
+			&prefs.HideDistance, &prefs.HideAge); err != nil {
+			utils.LogError("Error scanning nearby user", err)
+			continue
+		}
+		candidate.Bio = bio.String
+		location.City = city.String
+		location.State = state.String
+		location.Country = country.String
+		candidate.Location = &location
+		candidate.Preferences = &prefs // Only the privacy flags, used by PublicFor
+		candidate.IsActive = true
+		candidates = append(candidates, candidate)
+	}
+
+	return candidates, rows.Err()
+}
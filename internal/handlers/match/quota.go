@@ -0,0 +1,72 @@
+package match
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// GetQuota returns the user's remaining likes and super likes for today
+// @Summary Get swipe quota
+// @Description Get remaining daily likes and super likes and when they reset. Allowances depend on the subscription tier and reset at midnight in the user's timezone. A limit of -1 means unlimited.
+// @Tags Matching
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.SwipeQuota} "Swipe quota retrieved"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /matches/quota [get]
+func (h *Handler) GetQuota(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if h.Quotas == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	quota, err := h.Quotas.GetQuota(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to get swipe quota", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Swipe quota retrieved", quota)
+}
+
+// writeQuotaExceeded writes a 429 response with the quota and a Retry-After
+// header pointing at the next reset
+func writeQuotaExceeded(w http.ResponseWriter, action models.SwipeAction, quota *models.SwipeQuota) {
+	message := "Daily like limit reached"
+	switch action {
+	case models.SuperLike:
+		message = "No super likes left today"
+	case rewindAction:
+		message = "No rewinds left today"
+	}
+
+	retryAfter := int64(math.Ceil(time.Until(quota.ResetsAt).Seconds()))
+	if retryAfter < 0 {
+		retryAfter = 0
+	}
+
+	w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+	utils.WriteJSONResponse(w, http.StatusTooManyRequests, models.QuotaExceededResponse{
+		Success:    false,
+		Error:      message,
+		Code:       http.StatusTooManyRequests,
+		Action:     action,
+		RetryAfter: retryAfter,
+		Quota:      quota,
+	})
+}
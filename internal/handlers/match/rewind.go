@@ -0,0 +1,152 @@
+package match
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// rewindAction identifies rewinds in quota errors
+const rewindAction models.SwipeAction = "rewind"
+
+// Rewind undoes the user's most recent swipe
+// @Summary Undo last swipe
+// @Description Revert the most recent swipe if it was made within the rewind window and did not create a match. The profile returns to the top of the deck and any like or super like spent on it today is refunded. Each rewind counts against the daily rewind allowance.
+// @Tags Matching
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=object{swipe=models.Swipe,quota=models.SwipeQuota}} "Swipe undone"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "No swipe to undo"
+// @Failure 409 {object} models.ErrorResponse "Swipe is too old, created a match or was already undone"
+// @Failure 429 {object} models.QuotaExceededResponse "Daily rewind allowance used up"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /matches/rewind [post]
+func (h *Handler) Rewind(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if database.DB == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	var swipe models.Swipe
+	err := database.DB.QueryRow(`
+		SELECT id, user_id, target_id, action, created_at
+		FROM swipes WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, userClaims.UserID).Scan(&swipe.ID, &swipe.UserID, &swipe.TargetID, &swipe.Action, &swipe.CreatedAt)
+	if err == sql.ErrNoRows {
+		utils.WriteNotFound(w, "No swipe to undo")
+		return
+	}
+	if err != nil {
+		utils.LogError("Failed to load last swipe", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if time.Since(swipe.CreatedAt) > h.RewindWindow {
+		utils.WriteErrorResponse(w, "Swipe can no longer be undone", http.StatusConflict)
+		return
+	}
+
+	matched, err := h.hasMatchBetween(userClaims.UserID, swipe.TargetID)
+	if err != nil {
+		utils.LogError("Failed to check match for rewind", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if matched {
+		utils.WriteErrorResponse(w, "Swipe created a match and cannot be undone", http.StatusConflict)
+		return
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		utils.LogError("Failed to start rewind transaction", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			utils.LogError("Error rolling back rewind transaction", err)
+		}
+	}()
+
+	// Only delete the swipe that was checked, in case another one landed
+	// meanwhile. The row lock makes a concurrent rewind of the same swipe wait
+	// and then find nothing to delete, so it is undone and refunded only once.
+	var deletedID string
+	err = tx.QueryRow(`DELETE FROM swipes WHERE id = $1 RETURNING id`, swipe.ID).Scan(&deletedID)
+	if err == sql.ErrNoRows {
+		utils.WriteErrorResponse(w, "Swipe was already undone", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		utils.LogError("Failed to delete swipe", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	var quota *models.SwipeQuota
+	if h.Quotas != nil {
+		var allowed bool
+		quota, allowed, err = h.Quotas.ConsumeRewind(userClaims.UserID)
+		if err != nil {
+			utils.LogError("Failed to check rewind quota", err)
+			utils.WriteInternalError(w, err)
+			return
+		}
+		if !allowed {
+			writeQuotaExceeded(w, rewindAction, quota)
+			return
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		utils.LogError("Failed to commit rewind", err)
+		if h.Quotas != nil {
+			if err := h.Quotas.RefundRewind(userClaims.UserID); err != nil {
+				utils.LogError("Failed to refund rewind quota", err)
+			}
+		}
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if h.Quotas != nil {
+		// A swipe from an earlier day drew on that day's allowance, not today's
+		if err := h.Quotas.RefundAt(userClaims.UserID, swipe.Action, swipe.CreatedAt); err != nil {
+			utils.LogError("Failed to refund swipe quota", err)
+		}
+		if refreshed, err := h.Quotas.GetQuota(userClaims.UserID); err == nil {
+			quota = refreshed
+		}
+	}
+
+	// Put the profile back on top of the deck and drop decks cached without it
+	if h.RedisService != nil {
+		if err := h.RedisService.PrioritizeInDeck(userClaims.UserID, swipe.TargetID, 24*time.Hour); err != nil {
+			log.Printf("Warning: Failed to prioritize rewound profile: %v", err)
+		}
+	}
+	h.invalidatePotentialMatches(userClaims.UserID)
+
+	utils.WriteSuccessResponse(w, "Swipe undone", map[string]interface{}{
+		"swipe": swipe,
+		"quota": quota,
+	})
+}
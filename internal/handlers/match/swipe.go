@@ -1,7 +1,6 @@
 package match
 
 import (
-	"fmt"
 	"log"
 	"net/http"
 	"time"
@@ -9,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"matching-api/internal/middleware"
 	"matching-api/internal/models"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/utils"
 )
 
@@ -23,6 +23,7 @@ import (
 // @Success 200 {object} models.APIResponse{data=object{swipe=models.Swipe,is_match=bool,match_id=string}} "Swipe recorded successfully"
 // @Failure 400 {object} models.ErrorResponse "Bad request - validation failed or already swiped"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 429 {object} models.QuotaExceededResponse "Daily like or super like allowance used up"
 // @Failure 500 {object} models.ErrorResponse "Internal server error"
 // @Router /matches/swipe [post]
 func (h *Handler) Swipe(w http.ResponseWriter, r *http.Request) {
@@ -39,28 +40,68 @@ func (h *Handler) Swipe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	response, ok := h.performSwipe(w, userClaims.UserID, req.TargetID, req.Action)
+	if !ok {
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Swipe recorded successfully", response)
+}
+
+// performSwipe validates, records and charges a swipe and creates the match if
+// the like is mutual. It writes the error response itself and returns false
+// when the swipe is rejected.
+func (h *Handler) performSwipe(w http.ResponseWriter, userID, targetID string, action models.SwipeAction) (map[string]interface{}, bool) {
 	// Validate that user isn't swiping on themselves
-	if req.TargetID == userClaims.UserID {
+	if targetID == userID {
 		utils.WriteErrorResponse(w, "Cannot swipe on yourself", http.StatusBadRequest)
-		return
+		return nil, false
 	}
 
 	// Check if user has already swiped on this person
-	if h.hasAlreadySwiped(userClaims.UserID, req.TargetID) {
+	if h.hasAlreadySwiped(userID, targetID) {
 		utils.WriteErrorResponse(w, "Already swiped on this user", http.StatusBadRequest)
-		return
+		return nil, false
+	}
+
+	// Likes and super likes draw from the daily allowance
+	if h.Quotas != nil {
+		quota, allowed, err := h.Quotas.Consume(userID, action)
+		if err != nil {
+			// Don't block swiping when the quota store is unavailable
+			utils.LogError("Failed to check swipe quota", err)
+		} else if !allowed {
+			writeQuotaExceeded(w, action, quota)
+			return nil, false
+		}
 	}
 
 	// Create swipe record
 	swipe := &models.Swipe{
 		ID:        uuid.New().String(),
-		UserID:    userClaims.UserID,
-		TargetID:  req.TargetID,
-		Action:    req.Action,
+		UserID:    userID,
+		TargetID:  targetID,
+		Action:    action,
 		CreatedAt: time.Now(),
 	}
 
-	// TODO: Save swipe to database
+	if err := h.saveSwipe(swipe); err != nil {
+		utils.LogError("Failed to save swipe", err)
+		if h.Quotas != nil {
+			if err := h.Quotas.Refund(userID, action); err != nil {
+				utils.LogError("Failed to refund swipe quota", err)
+			}
+		}
+		utils.WriteInternalError(w, err)
+		return nil, false
+	}
+
+	// A rewound profile leaves the top of the deck once it is swiped again
+	if h.RedisService != nil {
+		if err := h.RedisService.RemoveFromDeckPriority(userID, targetID); err != nil {
+			log.Printf("Warning: Failed to update deck priority: %v", err)
+		}
+	}
 
 	response := map[string]interface{}{
 		"swipe":    swipe,
@@ -69,45 +110,44 @@ func (h *Handler) Swipe(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check for mutual like (match)
-	if req.Action == models.SwipeRight || req.Action == models.SuperLike {
-		if h.hasUserLikedBack(req.TargetID, userClaims.UserID) {
+	if action == models.SwipeRight || action == models.SuperLike {
+		if h.hasUserLikedBack(targetID, userID) {
 			// It's a match! Create match record
-			match := h.createMatch(userClaims.UserID, req.TargetID)
+			match := h.createMatch(userID, targetID)
+			if err := h.saveMatch(match); err != nil {
+				utils.LogError("Failed to save match", err)
+				utils.WriteInternalError(w, err)
+				return nil, false
+			}
 			response["is_match"] = true
 			response["match_id"] = match.ID
 			response["match"] = match
 
 			// Invalidate match caches for both users
-			h.invalidateMatchCaches(userClaims.UserID, req.TargetID)
+			h.invalidateMatchCaches(userID, targetID)
 
 			// TODO: Send notification to both users
 		}
 	}
 
-	utils.WriteSuccessResponse(w, "Swipe recorded successfully", response)
+	return response, true
 }
 
 // invalidateMatchCaches invalidates match-related caches for both users
 func (h *Handler) invalidateMatchCaches(userID1, userID2 string) {
-	if h.RedisService == nil {
-		return
+	for _, userID := range []string{userID1, userID2} {
+		// Clear paginated match lists
+		if err := h.Cache.InvalidateTags(cache.MatchesTag(userID)); err != nil {
+			log.Printf("Warning: Failed to invalidate match cache for user %s: %v", userID, err)
+		}
+		// Clear potential matches (they shouldn't see each other again)
+		h.invalidatePotentialMatches(userID)
 	}
+}
 
-	// Clear match cache for current user
-	if err := h.RedisService.Delete(fmt.Sprintf("matches_*_%s_*", userID1)); err != nil {
-		log.Printf("Warning: Failed to invalidate match cache for user %s: %v", userID1, err)
-	}
-	
-	// Clear match cache for target user
-	if err := h.RedisService.Delete(fmt.Sprintf("matches_*_%s_*", userID2)); err != nil {
-		log.Printf("Warning: Failed to invalidate match cache for user %s: %v", userID2, err)
+// invalidatePotentialMatches clears every cached discovery deck of a user
+func (h *Handler) invalidatePotentialMatches(userID string) {
+	if err := h.Cache.InvalidateTags(cache.PotentialMatchesTag(userID)); err != nil {
+		log.Printf("Warning: Failed to invalidate potential matches cache for user %s: %v", userID, err)
 	}
-	
-	// Clear potential matches cache for both users (they shouldn't see each other again)
-	if err := h.RedisService.Delete(fmt.Sprintf("potential_matches_%s_*", userID1)); err != nil {
-		log.Printf("Warning: Failed to invalidate potential matches cache: %v", err)
-	}
-	if err := h.RedisService.Delete(fmt.Sprintf("potential_matches_%s_*", userID2)); err != nil {
-		log.Printf("Warning: Failed to invalidate potential matches cache: %v", err)
-	}
-}
\ No newline at end of file
+}
@@ -1,26 +1,110 @@
 package match
 
 import (
+	"database/sql"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"matching-api/internal/database"
 	"matching-api/internal/models"
+	"matching-api/pkg/utils"
 )
 
 // hasAlreadySwiped checks if user has already swiped on target user
 func (h *Handler) hasAlreadySwiped(userID, targetID string) bool {
-	// Check database for existing swipe record
-	// TODO: Implement database query to check swipes table
-	// SELECT COUNT(*) FROM swipes WHERE user_id = ? AND target_user_id = ?
-	return false // Placeholder until database integration
+	if database.DB == nil {
+		return false // Placeholder until database integration
+	}
+
+	var exists bool
+	err := database.DB.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM swipes WHERE user_id = $1 AND target_id = $2)
+	`, userID, targetID).Scan(&exists)
+	if err != nil {
+		utils.LogError("Failed to check existing swipe", err)
+		return false
+	}
+	return exists
 }
 
 // hasUserLikedBack checks if target user has already liked the current user
 func (h *Handler) hasUserLikedBack(userID, targetID string) bool {
-	// Check if targetID has already liked userID in the database
-	// TODO: Implement database query to check mutual likes
-	// SELECT COUNT(*) FROM swipes WHERE user_id = ? AND target_user_id = ? AND swipe_type = 'like'
-	return time.Now().Unix()%3 == 0 // Placeholder simulation until database integration
+	if database.DB == nil {
+		return time.Now().Unix()%3 == 0 // Placeholder simulation until database integration
+	}
+
+	var liked bool
+	err := database.DB.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM swipes WHERE user_id = $1 AND target_id = $2 AND action IN ('right', 'super'))
+	`, userID, targetID).Scan(&liked)
+	if err != nil {
+		utils.LogError("Failed to check mutual like", err)
+		return false
+	}
+	return liked
+}
+
+// saveSwipe stores a swipe record
+func (h *Handler) saveSwipe(swipe *models.Swipe) error {
+	if database.DB == nil {
+		return nil
+	}
+
+	_, err := database.DB.Exec(`
+		INSERT INTO swipes (id, user_id, target_id, action, created_at) VALUES ($1, $2, $3, $4, $5)
+	`, swipe.ID, swipe.UserID, swipe.TargetID, swipe.Action, swipe.CreatedAt)
+	return err
+}
+
+// saveMatch stores a match together with the chat the two users talk in
+func (h *Handler) saveMatch(match *models.Match) error {
+	if database.DB == nil {
+		return nil
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			utils.LogError("Error rolling back match transaction", err)
+		}
+	}()
+
+	_, err = tx.Exec(`
+		INSERT INTO matches (id, user1_id, user2_id, is_active, created_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6)
+	`, match.ID, match.User1ID, match.User2ID, match.IsActive, match.CreatedAt, match.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO chats (id, match_id, created_at, updated_at) VALUES ($1, $2, $3, $3)
+	`, uuid.New().String(), match.ID, match.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// hasMatchBetween reports whether two users have a match, in either direction
+func (h *Handler) hasMatchBetween(userID, otherID string) (bool, error) {
+	if database.DB == nil {
+		return false, nil
+	}
+
+	var exists bool
+	err := database.DB.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM matches
+			WHERE (user1_id = $1 AND user2_id = $2) OR (user1_id = $2 AND user2_id = $1)
+		)
+	`, userID, otherID).Scan(&exists)
+	return exists, err
 }
 
 // createMatch creates a new match between two users
@@ -32,71 +116,214 @@ func (h *Handler) createMatch(user1ID, user2ID string) *models.Match {
 		IsActive:  true,
 		CreatedAt: time.Now(),
 	}
+	if h.Expiry != nil {
+		match.ExpiresAt = h.Expiry.ExpiresAt(match.CreatedAt)
+	}
 
-	// Populate user details (TODO: fetch from database)
-	match.User1 = h.simulateGetUser(user1ID).Public()
-	match.User2 = h.simulateGetUser(user2ID).Public()
+	// Populate user details (TODO: fetch from database). The match is returned
+	// to user1, so user2's profile is shown relative to user1's location.
+	user1 := h.simulateGetUser(user1ID)
+	match.User1 = user1.Public()
+	match.User2 = h.simulateGetUser(user2ID).PublicFor(user1.Location)
 
 	return match
 }
 
-// getUserMatches retrieves user matches from database with pagination
-func (h *Handler) getUserMatches(userID string, page, limit int) []models.Match {
-	// Retrieve user matches from database with pagination
-	// TODO: Implement database query with proper pagination
-	// SELECT m.*, u1.*, u2.* FROM matches m 
-	// JOIN users u1 ON m.user1_id = u1.id 
-	// JOIN users u2 ON m.user2_id = u2.id 
-	// WHERE (m.user1_id = ? OR m.user2_id = ?) AND m.is_active = true
-	// ORDER BY m.created_at DESC LIMIT ? OFFSET ?
-	
-	// Placeholder data until database integration
-	matches := []models.Match{
-		{
-			ID:        "match-1",
-			User1ID:   userID,
-			User2ID:   "user-456",
-			User2: &models.User{
-				ID:        "user-456",
-				FirstName: "Sarah",
-				Age:       26,
-				Gender:    "female",
-				Bio:       "Adventure seeker and coffee lover",
-				Photos: []models.Photo{
-					{URL: "https://example.com/photos/sarah1.jpg", Position: 1},
-				},
-			},
-			IsActive:  true,
-			CreatedAt: time.Now().Add(-24 * time.Hour),
-		},
-		{
-			ID:        "match-2",
-			User1ID:   userID,
-			User2ID:   "user-789",
-			User2: &models.User{
-				ID:        "user-789",
-				FirstName: "Emma",
-				Age:       29,
-				Gender:    "female",
-				Bio:       "Yoga instructor and foodie",
-				Photos: []models.Photo{
-					{URL: "https://example.com/photos/emma1.jpg", Position: 1},
-				},
-			},
-			IsActive:  true,
-			CreatedAt: time.Now().Add(-48 * time.Hour),
-		},
+// matchesCursor marks the last match of a page. Matches are ordered by most
+// recent activity, then ID.
+type matchesCursor struct {
+	ActiveAt time.Time
+	ID       string
+}
+
+// encode returns the opaque cursor string handed to clients
+func (c matchesCursor) encode() string {
+	return encodeCursor(strconv.FormatInt(c.ActiveAt.UnixNano(), 10), c.ID)
+}
+
+// decodeMatchesCursor parses a cursor produced by matchesCursor.encode
+func decodeMatchesCursor(value string) (*matchesCursor, error) {
+	parts, err := decodeCursor(value, 2)
+	if err != nil {
+		return nil, err
 	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uuid.Parse(parts[1]); err != nil {
+		return nil, err
+	}
+
+	return &matchesCursor{ActiveAt: time.Unix(0, nanos), ID: parts[1]}, nil
+}
+
+// matchFilterConditions narrows the match list to new matches or conversations
+var matchFilterConditions = map[models.MatchFilter]string{
+	models.MatchFilterAll:           "",
+	models.MatchFilterNew:           "AND c.last_message_at IS NULL",
+	models.MatchFilterConversations: "AND c.last_message_at IS NOT NULL",
+}
+
+// getUserMatches loads a page of the user's active matches after the cursor,
+// most recently active first, together with the total for the filter.
+// Matches with deactivated users are left out. The other user's public profile
+// is set as User2.
+func (h *Handler) getUserMatches(userID string, filter models.MatchFilter, cursor *matchesCursor, limit int) (*models.MatchesPage, error) {
+	filterCondition := matchFilterConditions[filter]
+
+	page := &models.MatchesPage{Matches: []models.Match{}, Filter: filter}
+	err := database.DB.QueryRow(`
+		SELECT COUNT(*) FROM matches m
+		LEFT JOIN chats c ON c.match_id = m.id
+		JOIN users u ON u.id = CASE WHEN m.user1_id = $1 THEN m.user2_id ELSE m.user1_id END
+		WHERE (m.user1_id = $1 OR m.user2_id = $1) AND m.is_active = true AND u.is_active = true `+filterCondition,
+		userID).Scan(&page.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{userID}
+	keyset := ""
+	if cursor != nil {
+		keyset = "AND (COALESCE(c.last_message_at, m.created_at), m.id) < ($2, $3::uuid)"
+		args = append(args, cursor.ActiveAt, cursor.ID)
+	}
+	args = append(args, limit+1)
+
+	viewer := h.loadViewerLocation(userID)
+
+	rows, err := database.DB.Query(fmt.Sprintf(`
+		SELECT m.id, m.created_at, m.expires_at, m.extended_at, c.id, c.last_message_at,
+		       COALESCE(c.last_message_at, m.created_at),
+		       u.id, u.first_name, u.age, u.bio, u.gender, u.latitude, u.longitude, u.last_seen,
+		       COALESCE(p.hide_distance, false), COALESCE(p.hide_age, false), ph.url
+		FROM matches m
+		LEFT JOIN chats c ON c.match_id = m.id
+		JOIN users u ON u.id = CASE WHEN m.user1_id = $1 THEN m.user2_id ELSE m.user1_id END
+		LEFT JOIN user_preferences p ON p.user_id = u.id
+		LEFT JOIN LATERAL (
+			SELECT url FROM images WHERE user_id = u.id AND is_active = true ORDER BY position LIMIT 1
+		) ph ON true
+		WHERE (m.user1_id = $1 OR m.user2_id = $1) AND m.is_active = true AND u.is_active = true %s %s
+		ORDER BY COALESCE(c.last_message_at, m.created_at) DESC, m.id DESC
+		LIMIT $%d
+	`, filterCondition, keyset, len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			utils.LogError("Error closing rows", err)
+		}
+	}()
+
+	var last matchesCursor
+	for rows.Next() {
+		if len(page.Matches) == limit {
+			page.NextCursor = last.encode()
+			break
+		}
+
+		var match models.Match
+		var other models.User
+		var prefs models.UserPrefs
+		var chatID, bio, photoURL sql.NullString
+		var lat, lng sql.NullFloat64
+		var activeAt time.Time
+		if err := rows.Scan(&match.ID, &match.CreatedAt, &match.ExpiresAt, &match.ExtendedAt, &chatID, &match.LastMessageAt,
+			&activeAt,
+			&other.ID, &other.FirstName, &other.Age, &bio, &other.Gender, &lat, &lng, &other.LastSeen,
+			&prefs.HideDistance, &prefs.HideAge, &photoURL); err != nil {
+			return nil, err
+		}
+
+		other.Bio = bio.String
+		other.Preferences = &prefs // Only the privacy flags, used by PublicFor
+		if lat.Valid && lng.Valid {
+			other.Location = &models.Location{Latitude: lat.Float64, Longitude: lng.Float64}
+		}
+		if photoURL.Valid {
+			other.Photos = []models.Photo{{UserID: other.ID, URL: photoURL.String, Position: 1}}
+		}
+
+		match.ChatID = chatID.String
+		match.User1ID = userID
+		match.User2ID = other.ID
+		match.User2 = other.PublicFor(viewer)
+		match.IsActive = true
+		page.Matches = append(page.Matches, match)
+		last = matchesCursor{ActiveAt: activeAt, ID: match.ID}
+	}
+
+	return page, rows.Err()
+}
+
+// isUserInMatch verifies if user is part of a specific active match
+func (h *Handler) isUserInMatch(userID, matchID string) (bool, error) {
+	var exists bool
+	err := database.DB.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1 FROM matches
+			WHERE id = $1 AND (user1_id = $2 OR user2_id = $2) AND is_active = true
+		)
+	`, matchID, userID).Scan(&exists)
+	return exists, err
+}
 
-	return matches
+// unmatchedMatch identifies the users and chat of a deactivated match
+type unmatchedMatch struct {
+	user1ID, user2ID string
+	chatID           string
 }
 
-// isUserInMatch verifies if user is part of a specific match
-func (h *Handler) isUserInMatch(userID, matchID string) bool {
-	// Verify user is part of the match in database
-	// TODO: Implement database query to verify match ownership
-	// SELECT COUNT(*) FROM matches WHERE id = ? AND (user1_id = ? OR user2_id = ?) AND is_active = true
-	return true // Placeholder until database integration
+// otherUserID returns the user in the match that isn't userID
+func (m *unmatchedMatch) otherUserID(userID string) string {
+	if m.user1ID == userID {
+		return m.user2ID
+	}
+	return m.user1ID
+}
+
+// deactivateMatch ends a match on behalf of one of its users and archives its
+// chat. It returns nil if the match was no longer active.
+func (h *Handler) deactivateMatch(matchID, userID, reason string) (*unmatchedMatch, error) {
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			utils.LogError("Error rolling back unmatch transaction", err)
+		}
+	}()
+
+	var unmatched unmatchedMatch
+	err = tx.QueryRow(`
+		UPDATE matches
+		SET is_active = false, unmatched_by = $2, unmatch_reason = NULLIF($3, ''), unmatched_at = NOW()
+		WHERE id = $1 AND (user1_id = $2 OR user2_id = $2) AND is_active = true
+		RETURNING user1_id, user2_id
+	`, matchID, userID, reason).Scan(&unmatched.user1ID, &unmatched.user2ID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Archiving the chat stops further messages
+	err = tx.QueryRow(`
+		UPDATE chats SET is_active = false, updated_at = NOW() WHERE match_id = $1 RETURNING id
+	`, matchID).Scan(&unmatched.chatID)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &unmatched, nil
 }
 
 // simulateGetUser simulates getting user data from database
@@ -138,10 +365,15 @@ func (h *Handler) simulateGetUserPreferences(userID string) *models.UserPrefs {
 
 // getPotentialUsers queries database for potential users based on preferences
 func (h *Handler) getPotentialUsers(user *models.User, prefs *models.UserPrefs) []models.User {
-	// Query database for potential users based on preferences and filters
-	// TODO: Implement complex database query with geolocation, preferences, and filtering
-	// This should exclude already swiped users, blocked users, and apply all preference filters
-	
+	// Radius, age, gender and swipe filters run in the database
+	if database.DB != nil && user.Location != nil {
+		candidates, err := h.queryNearbyUsers(user, prefs)
+		if err == nil {
+			return candidates
+		}
+		utils.LogError("Failed to query nearby users", err)
+	}
+
 	// Placeholder data until database integration
 	return []models.User{
 		{
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"matching-api/internal/handlers/shared"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/services"
 )
 
@@ -11,8 +12,8 @@ type Handler struct {
 }
 
 // NewHandler creates a new notification handler
-func NewHandler(redisService *services.RedisService) *Handler {
+func NewHandler(redisService *services.RedisService, cacheStore cache.Cache) *Handler {
 	return &Handler{
-		BaseHandler: shared.NewBaseHandler(redisService),
+		BaseHandler: shared.NewBaseHandler(redisService, cacheStore),
 	}
 }
@@ -1,17 +1,20 @@
 package shared
 
 import (
+	"matching-api/pkg/cache"
 	"matching-api/pkg/services"
 )
 
 // BaseHandler contains common dependencies used by all handlers
 type BaseHandler struct {
 	RedisService *services.RedisService
+	Cache        cache.Cache // Never nil, falls back to memory without Redis
 }
 
 // NewBaseHandler creates a new base handler with common dependencies
-func NewBaseHandler(redisService *services.RedisService) BaseHandler {
+func NewBaseHandler(redisService *services.RedisService, cacheStore cache.Cache) BaseHandler {
 	return BaseHandler{
 		RedisService: redisService,
+		Cache:        cacheStore,
 	}
-}
\ No newline at end of file
+}
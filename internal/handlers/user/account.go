@@ -0,0 +1,149 @@
+package user
+
+import (
+	"database/sql"
+	"net/http"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// DeleteAccount schedules the current user's account for deletion
+// @Summary Delete account
+// @Description Schedule the current account for permanent deletion. The password must be re-entered. The account is hidden immediately and purged after a grace period, during which the deletion can be cancelled.
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body models.DeleteAccountRequest true "Password confirmation"
+// @Success 200 {object} models.APIResponse{data=models.AccountDeletionStatus} "Account scheduled for deletion"
+// @Failure 400 {object} models.ErrorResponse "Bad request - validation failed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token or password"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/me [delete]
+func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	var req models.DeleteAccountRequest
+	if err := utils.ParseAndValidateJSON(r, &req); err != nil {
+		utils.WriteValidationError(w, err)
+		return
+	}
+
+	if database.DB == nil || h.AccountDeletion == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	// Re-authenticate before doing anything irreversible
+	var passwordHash string
+	err := database.DB.QueryRow(`SELECT password FROM users WHERE id = $1`, userClaims.UserID).Scan(&passwordHash)
+	if err == sql.ErrNoRows {
+		utils.WriteNotFound(w, "User not found")
+		return
+	}
+	if err != nil {
+		utils.LogError("Error loading user for account deletion", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(req.Password)); err != nil {
+		utils.WriteUnauthorized(w, "Invalid password")
+		return
+	}
+
+	status, err := h.AccountDeletion.ScheduleDeletion(userClaims.UserID, req.Reason)
+	if err != nil {
+		utils.LogError("Failed to schedule account deletion", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Account scheduled for deletion", status)
+}
+
+// GetAccountDeletion returns the current user's pending account deletion
+// @Summary Get pending account deletion
+// @Description Get when the current account is scheduled to be deleted
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.AccountDeletionStatus} "Account deletion pending"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "No account deletion pending"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/me/deletion [get]
+func (h *Handler) GetAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if database.DB == nil || h.AccountDeletion == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	status, err := h.AccountDeletion.GetDeletionStatus(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to get account deletion status", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if status == nil {
+		utils.WriteNotFound(w, "No account deletion pending")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Account deletion pending", status)
+}
+
+// CancelAccountDeletion cancels the current user's pending account deletion
+// @Summary Cancel account deletion
+// @Description Cancel a pending account deletion during the grace period and reactivate the account
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse "Account deletion cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "No account deletion pending"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/me/deletion [delete]
+func (h *Handler) CancelAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if database.DB == nil || h.AccountDeletion == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	cancelled, err := h.AccountDeletion.CancelDeletion(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to cancel account deletion", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if !cancelled {
+		utils.WriteNotFound(w, "No account deletion pending")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Account deletion cancelled", nil)
+}
@@ -0,0 +1,97 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"matching-api/internal/database"
+	"matching-api/internal/middleware"
+	"matching-api/pkg/utils"
+)
+
+// RequestDataExport queues an export of all of the current user's data
+// @Summary Request data export
+// @Description Queue a ZIP archive of everything stored about the current user: profile, preferences, original photos, swipes, matches, messages with their edit history, reactions and sent images, notifications and analytics events. The user is notified when it is ready. If an export is already in progress it is returned instead.
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} models.APIResponse{data=models.DataExport} "Data export already in progress"
+// @Success 201 {object} models.APIResponse{data=models.DataExport} "Data export queued"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/me/export [post]
+func (h *Handler) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	if h.S3Service == nil {
+		utils.WriteErrorResponse(w, "S3 service not configured", http.StatusInternalServerError)
+		return
+	}
+	if database.DB == nil || h.DataExports == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	export, created, err := h.DataExports.RequestExport(userClaims.UserID)
+	if err != nil {
+		utils.LogError("Failed to request data export", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+
+	if !created {
+		utils.WriteSuccessResponse(w, "Data export already in progress", export)
+		return
+	}
+	utils.WriteCreated(w, "Data export queued", export)
+}
+
+// GetDataExport returns the status of a data export and a download link once it is ready
+// @Summary Get data export
+// @Description Get the status of a data export. Completed exports include a download link valid for a limited time; request the export again for a fresh link. Archives are deleted after their expiry.
+// @Tags User Management
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param exportID path string true "Export ID"
+// @Success 200 {object} models.APIResponse{data=models.DataExport} "Data export retrieved"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized - invalid token"
+// @Failure 404 {object} models.ErrorResponse "Data export not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/me/export/{exportID} [get]
+func (h *Handler) GetDataExport(w http.ResponseWriter, r *http.Request) {
+	userClaims, ok := middleware.GetUserFromContext(r.Context())
+	if !ok {
+		utils.WriteUnauthorized(w, "User not found in context")
+		return
+	}
+
+	exportID := chi.URLParam(r, "exportID")
+	if exportID == "" {
+		utils.WriteErrorResponse(w, "Export ID is required", http.StatusBadRequest)
+		return
+	}
+
+	if database.DB == nil || h.DataExports == nil {
+		utils.WriteInternalError(w, nil)
+		return
+	}
+
+	export, err := h.DataExports.GetExport(r.Context(), userClaims.UserID, exportID)
+	if err != nil {
+		utils.LogError("Failed to get data export", err)
+		utils.WriteInternalError(w, err)
+		return
+	}
+	if export == nil {
+		utils.WriteNotFound(w, "Data export not found")
+		return
+	}
+
+	utils.WriteSuccessResponse(w, "Data export retrieved", export)
+}
@@ -2,19 +2,25 @@ package user
 
 import (
 	"matching-api/internal/handlers/shared"
+	internalServices "matching-api/internal/services"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/services"
 )
 
 // Handler handles user profile-related requests
 type Handler struct {
 	shared.BaseHandler
-	S3Service *services.S3Service
+	S3Service       *services.S3Service
+	AccountDeletion *internalServices.AccountDeletionService
+	DataExports     *internalServices.DataExportService
 }
 
 // NewHandler creates a new user handler
-func NewHandler(s3Service *services.S3Service, redisService *services.RedisService) *Handler {
+func NewHandler(s3Service *services.S3Service, redisService *services.RedisService, cacheStore cache.Cache, accountDeletion *internalServices.AccountDeletionService, dataExports *internalServices.DataExportService) *Handler {
 	return &Handler{
-		BaseHandler: shared.NewBaseHandler(redisService),
-		S3Service:   s3Service,
+		BaseHandler:     shared.NewBaseHandler(redisService, cacheStore),
+		S3Service:       s3Service,
+		AccountDeletion: accountDeletion,
+		DataExports:     dataExports,
 	}
 }
\ No newline at end of file
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"matching-api/internal/database"
 	"matching-api/internal/middleware"
 	"matching-api/internal/models"
 	"matching-api/pkg/utils"
@@ -80,6 +81,7 @@ func (h *Handler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
 			MaxDistance: 50,
 			InterestedIn: []string{"female"},
 			ShowMe:    "everyone",
+			SendReadReceipts: true,
 			CreatedAt: time.Now(),
 		}
 	}
@@ -109,6 +111,23 @@ func (h *Handler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
 	if req.HideAge != nil {
 		preferences.HideAge = *req.HideAge
 	}
+	if req.SendReadReceipts != nil {
+		preferences.SendReadReceipts = *req.SendReadReceipts
+
+		// Read receipts are checked when messages are read, so they are saved right away
+		if database.DB != nil {
+			_, err := database.DB.Exec(`
+				INSERT INTO user_preferences (id, user_id, send_read_receipts)
+				VALUES ($1, $2, $3)
+				ON CONFLICT (user_id) DO UPDATE SET send_read_receipts = EXCLUDED.send_read_receipts, updated_at = NOW()
+			`, uuid.New().String(), userClaims.UserID, preferences.SendReadReceipts)
+			if err != nil {
+				utils.LogError("Failed to save read receipt preference", err)
+				utils.WriteInternalError(w, err)
+				return
+			}
+		}
+	}
 
 	preferences.UpdatedAt = time.Now()
 
@@ -7,6 +7,7 @@ import (
 
 	"matching-api/internal/middleware"
 	"matching-api/internal/models"
+	"matching-api/pkg/cache"
 	"matching-api/pkg/utils"
 )
 
@@ -30,14 +31,12 @@ func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Try to get user from Redis cache first
+	// Try to get user from cache first
 	var user *models.User
-	if h.RedisService != nil {
-		var cachedUser models.User
-		if err := h.RedisService.GetCachedUser(userClaims.UserID, &cachedUser); err == nil {
-			user = &cachedUser
-			log.Printf("User profile served from cache for user: %s", userClaims.UserID)
-		}
+	var cachedUser models.User
+	if err := h.Cache.Get(cache.UserKey(userClaims.UserID), &cachedUser); err == nil {
+		user = &cachedUser
+		log.Printf("User profile served from cache for user: %s", userClaims.UserID)
 	}
 	
 	// If not in cache, get from database
@@ -49,10 +48,8 @@ func (h *Handler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		}
 		
 		// Cache the user data for future requests
-		if h.RedisService != nil {
-			if err := h.RedisService.CacheUser(user.ID, user, 30*time.Minute); err != nil {
-				log.Printf("Warning: Failed to cache user profile: %v", err)
-			}
+		if err := h.Cache.Set(cache.UserKey(user.ID), user, 30*time.Minute); err != nil {
+			log.Printf("Warning: Failed to cache user profile: %v", err)
 		}
 	}
 
@@ -107,25 +104,34 @@ func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		user.Bio = *req.Bio
 	}
 	if req.Location != nil {
+		if err := h.updateUserLocation(user.ID, req.Location); err != nil {
+			utils.LogError("Failed to update user location", err)
+			utils.WriteInternalError(w, err)
+			return
+		}
 		user.Location = req.Location
 	}
+	if req.Timezone != nil {
+		if err := h.updateUserTimezone(user.ID, *req.Timezone); err != nil {
+			utils.LogError("Failed to update user timezone", err)
+			utils.WriteInternalError(w, err)
+			return
+		}
+	}
 
 	user.UpdatedAt = time.Now()
 
 	// TODO: Save updated user to database
 	
 	// Invalidate cache and update with new data
-	if h.RedisService != nil {
-		// Clear old cache
-		if err := h.RedisService.InvalidateUserCache(userClaims.UserID); err != nil {
-			log.Printf("Warning: Failed to invalidate user cache: %v", err)
-		}
-		
-		// Cache updated user data
-		if err := h.RedisService.CacheUser(user.ID, user.Public(), 30*time.Minute); err != nil {
-			log.Printf("Warning: Failed to cache updated user data: %v", err)
-		}
+	if err := cache.InvalidateUser(h.Cache, userClaims.UserID); err != nil {
+		log.Printf("Warning: Failed to invalidate user cache: %v", err)
+	}
+
+	// Cache updated user data
+	if err := h.Cache.Set(cache.UserKey(user.ID), user, 30*time.Minute); err != nil {
+		log.Printf("Warning: Failed to cache updated user data: %v", err)
 	}
 	
-	utils.WriteSuccessResponse(w, "Profile updated successfully", user.Public())
+	utils.WriteSuccessResponse(w, "Profile updated successfully", user)
 }
\ No newline at end of file
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"matching-api/internal/database"
 	"matching-api/internal/models"
+	"matching-api/pkg/geo"
 )
 
 // getUserProfile retrieves user profile from database
@@ -43,6 +45,33 @@ func (h *Handler) getUserProfile(userID string) *models.User {
 	}
 }
 
+// updateUserLocation saves a user's location together with its geohash, which
+// discovery uses for radius queries when PostGIS is not installed
+func (h *Handler) updateUserLocation(userID string, location *models.Location) error {
+	if database.DB == nil {
+		return nil
+	}
+
+	_, err := database.DB.Exec(`
+		UPDATE users
+		SET latitude = $1, longitude = $2, city = NULLIF($3, ''), state = NULLIF($4, ''), country = NULLIF($5, ''),
+		    geohash = $6, updated_at = NOW()
+		WHERE id = $7
+	`, location.Latitude, location.Longitude, location.City, location.State, location.Country,
+		geo.Encode(location.Latitude, location.Longitude, geo.MaxPrecision), userID)
+	return err
+}
+
+// updateUserTimezone saves the timezone used for daily swipe allowance resets
+func (h *Handler) updateUserTimezone(userID, timezone string) error {
+	if database.DB == nil {
+		return nil
+	}
+
+	_, err := database.DB.Exec(`UPDATE users SET timezone = $1, updated_at = NOW() WHERE id = $2`, timezone, userID)
+	return err
+}
+
 // simulatePhotoUpload simulates photo upload and returns a URL
 func (h *Handler) simulatePhotoUpload(filename string) string {
 	// TODO: Implement real cloud storage upload
@@ -74,17 +103,18 @@ func (h *Handler) getUserPreferences(userID string) *models.UserPrefs {
 	
 	// Placeholder mock data until database integration
 	return &models.UserPrefs{
-		ID:           "pref-1",
-		UserID:       userID,
-		AgeMin:       22,
-		AgeMax:       35,
-		MaxDistance:  25,
-		InterestedIn: []string{"female"},
-		ShowMe:       "everyone",
-		OnlyVerified: false,
-		HideDistance: false,
-		HideAge:      false,
-		CreatedAt:    time.Now().AddDate(0, -1, 0),
-		UpdatedAt:    time.Now(),
+		ID:               "pref-1",
+		UserID:           userID,
+		AgeMin:           22,
+		AgeMax:           35,
+		MaxDistance:      25,
+		InterestedIn:     []string{"female"},
+		ShowMe:           "everyone",
+		OnlyVerified:     false,
+		HideDistance:     false,
+		HideAge:          false,
+		SendReadReceipts: true,
+		CreatedAt:        time.Now().AddDate(0, -1, 0),
+		UpdatedAt:        time.Now(),
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"os"
+	"strings"
 
 	"matching-api/internal/models"
 	"matching-api/pkg/auth"
@@ -17,43 +18,125 @@ const (
 	UserContextKey contextKey = "user"
 )
 
-// AuthMiddleware validates JWT tokens and sets user context
-func AuthMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Get JWT secret from environment
-		jwtSecret := os.Getenv("JWT_SECRET")
-		if jwtSecret == "" {
-			jwtSecret = "your-super-secret-key-change-this-in-production"
-		}
+// AuthMiddleware validates JWT tokens, rejects revoked tokens and sets user context
+func AuthMiddleware(revocations auth.RevocationStore) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Already authenticated by OptionalAuth
+			if _, ok := GetUserFromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
 
-		jwtService := auth.NewJWTService(jwtSecret)
+			claims, message := authenticate(revocations, r)
+			if claims == nil {
+				utils.WriteErrorResponse(w, message, http.StatusUnauthorized)
+				return
+			}
 
-		// Extract token from Authorization header
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			utils.WriteErrorResponse(w, "Authorization header required", http.StatusUnauthorized)
-			return
-		}
+			// Add user info to context
+			ctx := context.WithValue(r.Context(), UserContextKey, claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
 
-		token := auth.ExtractTokenFromHeader(authHeader)
-		if token == "" {
-			utils.WriteErrorResponse(w, "Invalid authorization header format", http.StatusUnauthorized)
-			return
-		}
+// OptionalAuth sets user context when the request carries a valid token and
+// lets every request through otherwise, so middleware such as rate limiting
+// can tell authenticated requests apart before AuthMiddleware runs
+func OptionalAuth(revocations auth.RevocationStore) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "" {
+				if claims, _ := authenticate(revocations, r); claims != nil {
+					r = r.WithContext(context.WithValue(r.Context(), UserContextKey, claims))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authenticate validates the request's bearer token, returning its claims or
+// the reason it was refused
+func authenticate(revocations auth.RevocationStore, r *http.Request) (*models.JWTClaims, string) {
+	// Get JWT secret from environment
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "your-super-secret-key-change-this-in-production"
+	}
+
+	jwtService := auth.NewJWTService(jwtSecret)
+
+	// Extract token from Authorization header
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return nil, "Authorization header required"
+	}
+
+	token := auth.ExtractTokenFromHeader(authHeader)
+	if token == "" {
+		return nil, "Invalid authorization header format"
+	}
 
-		// Validate token
-		claims, err := jwtService.ValidateAccessToken(token)
+	// Validate token
+	claims, err := jwtService.ValidateAccessToken(token)
+	if err != nil {
+		return nil, "Invalid or expired token"
+	}
+
+	// Reject tokens that were revoked before they expired. A store error is
+	// logged and the request let through so an outage does not lock out every user.
+	if revocations != nil {
+		revoked, err := auth.IsClaimsRevoked(revocations, claims)
 		if err != nil {
-			utils.WriteErrorResponse(w, "Invalid or expired token", http.StatusUnauthorized)
-			return
+			utils.LogError("Failed to check token revocation", err)
+		} else if revoked {
+			return nil, "Token has been revoked"
 		}
+	}
+
+	return claims, ""
+}
 
-		// Add user info to context
-		ctx := context.WithValue(r.Context(), UserContextKey, claims)
-		next.ServeHTTP(w, r.WithContext(ctx))
+// WebSocketTokenProtocol is offered as a WebSocket subprotocol, followed by the
+// access token, by clients that can't set the Authorization header. The server
+// only echoes this name back, never the token.
+const WebSocketTokenProtocol = "access_token"
+
+// WebSocketToken lets WebSocket upgrade requests pass the access token in the
+// Sec-WebSocket-Protocol header, as the protocol after WebSocketTokenProtocol,
+// since browsers can't set the Authorization header on them. Unlike a query
+// parameter, the header never ends up in request logs. It must run before
+// AuthMiddleware.
+func WebSocketToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" && strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			if token := webSocketProtocolToken(r); token != "" {
+				r.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		next.ServeHTTP(w, r)
 	})
 }
 
+// webSocketProtocolToken returns the protocol offered after
+// WebSocketTokenProtocol, if any
+func webSocketProtocolToken(r *http.Request) string {
+	var protocols []string
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			protocols = append(protocols, strings.TrimSpace(protocol))
+		}
+	}
+	for i := 0; i+1 < len(protocols); i++ {
+		if protocols[i] == WebSocketTokenProtocol {
+			return protocols[i+1]
+		}
+	}
+	return ""
+}
+
 // GetUserFromContext extracts user claims from request context
 func GetUserFromContext(ctx context.Context) (*models.JWTClaims, bool) {
 	user, ok := ctx.Value(UserContextKey).(*models.JWTClaims)
@@ -0,0 +1,205 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/httprate"
+	"matching-api/internal/models"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/ratelimit"
+	"matching-api/pkg/services"
+	"matching-api/pkg/utils"
+)
+
+// ServiceTokenHeader carries the token internal services use to bypass rate limits
+const ServiceTokenHeader = "X-Service-Token"
+
+// DeviceIDHeader identifies the client device for policies keyed by device
+const DeviceIDHeader = "X-Device-ID"
+
+// tierCacheTTL is how long a user's subscription tier is cached for rate limiting
+const tierCacheTTL = 5 * time.Minute
+
+// TierResolver returns a user's subscription tier
+type TierResolver func(userID string) (models.SubscriptionTier, error)
+
+// RateLimits enforces the named policies of the rate limit configuration
+type RateLimits struct {
+	limiter *ratelimit.Limiter
+	config  *ratelimit.Config
+	cache   cache.Cache
+	tiers   TierResolver
+}
+
+// NewRateLimits creates rate limits backed by Redis when available, falling
+// back to in-memory counters otherwise. Tiers may be nil if no policy has
+// per-tier limits.
+func NewRateLimits(redisService *services.RedisService, cacheStore cache.Cache, config *ratelimit.Config, tiers TierResolver) *RateLimits {
+	return &RateLimits{
+		limiter: ratelimit.NewLimiter(redisService),
+		config:  config,
+		cache:   cacheStore,
+		tiers:   tiers,
+	}
+}
+
+// Limit returns middleware enforcing the named policy. Responses carry
+// RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and RateLimit-Policy
+// headers, and rejected requests get a 429 with Retry-After. Policies keyed by
+// user must be used after AuthMiddleware. Requests are let through if the
+// limiter is unavailable.
+func (rl *RateLimits) Limit(name string) func(next http.Handler) http.Handler {
+	return rl.limit(name, false)
+}
+
+// LimitAnonymous is like Limit but only counts requests without an
+// authenticated user, leaving those to user-keyed policies and their tiers. It
+// must be used after OptionalAuth.
+func (rl *RateLimits) LimitAnonymous(name string) func(next http.Handler) http.Handler {
+	return rl.limit(name, true)
+}
+
+func (rl *RateLimits) limit(name string, anonymousOnly bool) func(next http.Handler) http.Handler {
+	policy, ok := rl.config.Policies[name]
+	if !ok {
+		panic(fmt.Sprintf("unknown rate limit policy %q", name))
+	}
+
+	return func(next http.Handler) http.Handler {
+		if !rl.config.Enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if rl.isExempt(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if _, ok := GetUserFromContext(r.Context()); ok && anonymousOnly {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key, userID, err := rateLimitKey(policy.Key, r)
+			if err != nil {
+				utils.LogError("Failed to derive rate limit key", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			limit := policy.Limit
+			if userID != "" && len(policy.Tiers) > 0 {
+				limit = policy.LimitFor(string(rl.tier(userID)))
+			}
+
+			result, err := rl.limiter.Allow(name+":"+key, limit, policy.Window)
+			if err != nil {
+				utils.LogError("Rate limiter unavailable", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result.SetHeaders(w.Header(), policy.Window)
+
+			if !result.Allowed {
+				utils.WriteTooManyRequests(w, "Too many requests, please slow down", result.RetryAfter)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AllowUser counts one request by the user against the named policy, for
+// requests that don't arrive over HTTP, such as WebSocket frames. It shares
+// counters with Limit for policies keyed by user and returns how long to wait
+// if the request is rejected. Requests are let through if the limiter is
+// unavailable.
+func (rl *RateLimits) AllowUser(name, userID string) (bool, time.Duration) {
+	policy, ok := rl.config.Policies[name]
+	if !ok {
+		panic(fmt.Sprintf("unknown rate limit policy %q", name))
+	}
+	if !rl.config.Enabled {
+		return true, 0
+	}
+
+	limit := policy.Limit
+	if len(policy.Tiers) > 0 {
+		limit = policy.LimitFor(string(rl.tier(userID)))
+	}
+
+	result, err := rl.limiter.Allow(name+":user:"+userID, limit, policy.Window)
+	if err != nil {
+		utils.LogError("Rate limiter unavailable", err)
+		return true, 0
+	}
+	return result.Allowed, result.RetryAfter
+}
+
+// isExempt reports whether the request carries an internal service token
+func (rl *RateLimits) isExempt(r *http.Request) bool {
+	token := r.Header.Get(ServiceTokenHeader)
+	if token == "" {
+		return false
+	}
+	for _, exempt := range rl.config.ExemptTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(exempt)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// tier returns the user's subscription tier, cached briefly so limits don't
+// cost a database query per request. Unknown tiers count as free.
+func (rl *RateLimits) tier(userID string) models.SubscriptionTier {
+	if rl.tiers == nil {
+		return models.TierFree
+	}
+
+	cacheKey := fmt.Sprintf("rate_limit_tier:%s", userID)
+	var tier models.SubscriptionTier
+	if err := rl.cache.Get(cacheKey, &tier); err == nil {
+		return tier
+	}
+
+	tier, err := rl.tiers(userID)
+	if err != nil {
+		utils.LogError("Failed to load subscription tier for rate limiting", err)
+		return models.TierFree
+	}
+	if err := rl.cache.Set(cacheKey, tier, tierCacheTTL); err != nil {
+		utils.LogError("Failed to cache subscription tier", err)
+	}
+	return tier
+}
+
+// rateLimitKey returns what a policy counts the request by, and the
+// authenticated user if any. Device IDs are chosen by the client, so device
+// policies should be paired with a user or IP policy.
+func rateLimitKey(keyBy string, r *http.Request) (key, userID string, err error) {
+	if userClaims, ok := GetUserFromContext(r.Context()); ok {
+		userID = userClaims.UserID
+	}
+
+	if keyBy == ratelimit.KeyByDevice {
+		if deviceID := r.Header.Get(DeviceIDHeader); deviceID != "" && len(deviceID) <= 128 {
+			return "device:" + deviceID, userID, nil
+		}
+	}
+	if keyBy != ratelimit.KeyByIP && userID != "" {
+		return "user:" + userID, userID, nil
+	}
+
+	// Fall back to IP-based rate limiting
+	ip, err := httprate.KeyByIP(r)
+	if err != nil {
+		return "", userID, err
+	}
+	return "ip:" + ip, userID, nil
+}
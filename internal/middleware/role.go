@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+
+	"matching-api/internal/models"
+	"matching-api/pkg/utils"
+)
+
+// RequireRole allows the request through only if the authenticated user's role
+// is at least the given role. Roles are hierarchical: admins can do everything
+// moderators can. Must be used after AuthMiddleware.
+func RequireRole(min models.Role) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userClaims, ok := GetUserFromContext(r.Context())
+			if !ok {
+				utils.WriteUnauthorized(w, "User not found in context")
+				return
+			}
+
+			if !userClaims.Role.HasAtLeast(min) {
+				utils.WriteForbidden(w, "Insufficient permissions")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
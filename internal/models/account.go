@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// DeleteAccountRequest represents a request to delete the current account
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+	Reason   string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
+// AccountDeletionStatus represents a pending account deletion
+type AccountDeletionStatus struct {
+	RequestedAt  time.Time `json:"requested_at"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+// AccountDeletionReceipt is the audit record kept after an account is purged.
+// It holds no personal data beyond a hash of the email address.
+type AccountDeletionReceipt struct {
+	ID                        string    `json:"id" db:"id"`
+	UserID                    string    `json:"user_id" db:"user_id"`
+	EmailHash                 string    `json:"email_hash" db:"email_hash"`
+	Reason                    string    `json:"reason,omitempty" db:"reason"`
+	RequestedAt               time.Time `json:"requested_at" db:"requested_at"`
+	CompletedAt               time.Time `json:"completed_at" db:"completed_at"`
+	StorageObjectsDeleted     int       `json:"storage_objects_deleted" db:"storage_objects_deleted"`
+	AnalyticsEventsAnonymized int64     `json:"analytics_events_anonymized" db:"analytics_events_anonymized"`
+}
+
+// DataExportStatus represents the state of a data export job
+type DataExportStatus string
+
+const (
+	DataExportPending    DataExportStatus = "pending"
+	DataExportProcessing DataExportStatus = "processing"
+	DataExportCompleted  DataExportStatus = "completed"
+	DataExportFailed     DataExportStatus = "failed"
+)
+
+// DataExport represents a user's request for a copy of their data
+type DataExport struct {
+	ID          string           `json:"id" db:"id"`
+	UserID      string           `json:"user_id" db:"user_id"`
+	Status      DataExportStatus `json:"status" db:"status"`
+	S3Key       string           `json:"-" db:"s3_key"`
+	Size        int64            `json:"size,omitempty" db:"size"`
+	Error       string           `json:"error,omitempty" db:"error"`
+	DownloadURL string           `json:"download_url,omitempty"` // Presigned, only set when completed
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt   *time.Time       `json:"expires_at,omitempty" db:"expires_at"`
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+)
+
+// Role represents a user's access level
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// roleRanks orders roles so that higher roles inherit lower-role permissions
+var roleRanks = map[Role]int{
+	RoleUser:      0,
+	RoleModerator: 1,
+	RoleAdmin:     2,
+}
+
+// IsValid reports whether the role is a known role
+func (r Role) IsValid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// HasAtLeast reports whether the role grants the permissions of min
+func (r Role) HasAtLeast(min Role) bool {
+	rank, ok := roleRanks[r]
+	if !ok {
+		return false
+	}
+	return rank >= roleRanks[min]
+}
+
+// ModerationActionType represents a moderation action taken by staff
+type ModerationActionType string
+
+const (
+	ModerationSuspendUser   ModerationActionType = "suspend_user"
+	ModerationUnsuspendUser ModerationActionType = "unsuspend_user"
+	ModerationChangeRole    ModerationActionType = "change_role"
+	ModerationApproveImage  ModerationActionType = "approve_image"
+	ModerationRemoveImage   ModerationActionType = "remove_image"
+)
+
+// ModerationAction represents an entry in the moderation audit log
+type ModerationAction struct {
+	ID           string                 `json:"id" db:"id"`
+	ModeratorID  *string                `json:"moderator_id,omitempty" db:"moderator_id"`
+	TargetUserID string                 `json:"target_user_id" db:"target_user_id"`
+	Action       ModerationActionType   `json:"action" db:"action"`
+	Reason       string                 `json:"reason,omitempty" db:"reason"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty" db:"metadata"`
+	CreatedAt    time.Time              `json:"created_at" db:"created_at"`
+}
+
+// SuspendUserRequest represents a request to suspend a user
+type SuspendUserRequest struct {
+	Reason        string `json:"reason" validate:"required,min=3,max=500"`
+	DurationHours int    `json:"duration_hours,omitempty" validate:"omitempty,min=1,max=8760"` // omit for an indefinite suspension
+}
+
+// UpdateRoleRequest represents a request to change a user's role
+type UpdateRoleRequest struct {
+	Role Role `json:"role" validate:"required,oneof=user moderator admin"`
+}
+
+// ModerateImageRequest represents a moderation decision on an image
+type ModerateImageRequest struct {
+	Action string `json:"action" validate:"required,oneof=approve remove"`
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
@@ -16,6 +16,7 @@ const (
 	EventProfileUpdated  EventType = "profile_updated"
 	EventPhotoUploaded   EventType = "photo_uploaded"
 	EventPhotoDeleted    EventType = "photo_deleted"
+	EventAccountLocked   EventType = "account_locked"
 
 	// Matching events
 	EventSwipeLeft      EventType = "swipe_left"
@@ -239,6 +240,7 @@ func GetEventDescription(eventType EventType) string {
 	descriptions := map[EventType]string{
 		EventUserLogin:         "User logged in",
 		EventUserLogout:        "User logged out",
+		EventAccountLocked:     "Account locked after failed logins",
 		EventUserRegistered:    "User registered",
 		EventProfileViewed:     "Profile viewed",
 		EventSwipeLeft:         "Swiped left (pass)",
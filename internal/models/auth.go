@@ -4,18 +4,22 @@ import "time"
 
 // LoginRequest represents the login request payload
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required,min=8"`
+	DeviceName string `json:"device_name,omitempty" validate:"omitempty,max=100"`
+	Platform   string `json:"platform,omitempty" validate:"omitempty,oneof=ios android web"`
 }
 
 // RegisterRequest represents the registration request payload
 type RegisterRequest struct {
-	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required,min=8"`
-	FirstName string `json:"first_name" validate:"required,min=2,max=50"`
-	LastName  string `json:"last_name" validate:"required,min=2,max=50"`
-	Age       int    `json:"age" validate:"required,min=18,max=100"`
-	Gender    string `json:"gender" validate:"required,oneof=male female non-binary"`
+	Email      string `json:"email" validate:"required,email"`
+	Password   string `json:"password" validate:"required,min=8"`
+	FirstName  string `json:"first_name" validate:"required,min=2,max=50"`
+	LastName   string `json:"last_name" validate:"required,min=2,max=50"`
+	Age        int    `json:"age" validate:"required,min=18,max=100"`
+	Gender     string `json:"gender" validate:"required,oneof=male female non-binary"`
+	DeviceName string `json:"device_name,omitempty" validate:"omitempty,max=100"`
+	Platform   string `json:"platform,omitempty" validate:"omitempty,oneof=ios android web"`
 }
 
 // AuthResponse represents the authentication response
@@ -33,10 +37,27 @@ type RefreshTokenRequest struct {
 
 // JWTClaims represents the JWT token claims
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Exp    int64  `json:"exp"`
-	Iat    int64  `json:"iat"`
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Role      Role   `json:"role"`
+	SessionID string `json:"sid"`
+	TokenID   string `json:"jti"`
+	Exp       int64  `json:"exp"`
+	Iat       int64  `json:"iat"`
+	IatMs     int64  `json:"iat_ms,omitempty"` // Issue time in milliseconds, zero on older tokens
+}
+
+// Session represents a logged-in device for a user
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	DeviceName string    `json:"device_name"`
+	Platform   string    `json:"platform,omitempty"` // ios, android, web
+	IPAddress  string    `json:"ip_address,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
 }
 
 // RefreshToken represents stored refresh tokens
@@ -56,18 +77,20 @@ type UpdateProfileRequest struct {
 	Age       *int      `json:"age,omitempty" validate:"omitempty,min=18,max=100"`
 	Bio       *string   `json:"bio,omitempty" validate:"omitempty,max=500"`
 	Location  *Location `json:"location,omitempty"`
+	Timezone  *string   `json:"timezone,omitempty" validate:"omitempty,timezone"` // IANA name, used for daily resets
 }
 
 // UpdatePreferencesRequest represents preferences update request
 type UpdatePreferencesRequest struct {
-	AgeMin       *int     `json:"age_min,omitempty" validate:"omitempty,min=18,max=100"`
-	AgeMax       *int     `json:"age_max,omitempty" validate:"omitempty,min=18,max=100"`
-	MaxDistance  *int     `json:"max_distance,omitempty" validate:"omitempty,min=1,max=100"`
-	InterestedIn []string `json:"interested_in,omitempty" validate:"omitempty,dive,oneof=male female non-binary"`
-	ShowMe       *string  `json:"show_me,omitempty" validate:"omitempty,oneof=male female non-binary everyone"`
-	OnlyVerified *bool    `json:"only_verified,omitempty"`
-	HideDistance *bool    `json:"hide_distance,omitempty"`
-	HideAge      *bool    `json:"hide_age,omitempty"`
+	AgeMin           *int     `json:"age_min,omitempty" validate:"omitempty,min=18,max=100"`
+	AgeMax           *int     `json:"age_max,omitempty" validate:"omitempty,min=18,max=100"`
+	MaxDistance      *int     `json:"max_distance,omitempty" validate:"omitempty,min=1,max=100"`
+	InterestedIn     []string `json:"interested_in,omitempty" validate:"omitempty,dive,oneof=male female non-binary"`
+	ShowMe           *string  `json:"show_me,omitempty" validate:"omitempty,oneof=male female non-binary everyone"`
+	OnlyVerified     *bool    `json:"only_verified,omitempty"`
+	HideDistance     *bool    `json:"hide_distance,omitempty"`
+	HideAge          *bool    `json:"hide_age,omitempty"`
+	SendReadReceipts *bool    `json:"send_read_receipts,omitempty"`
 }
 
 // APIResponse represents a standard API response
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Boost temporarily ranks a user higher in nearby users' discovery decks
+type Boost struct {
+	ID        string      `json:"id" db:"id"`
+	UserID    string      `json:"user_id" db:"user_id"`
+	StartedAt time.Time   `json:"started_at" db:"started_at"`
+	EndsAt    time.Time   `json:"ends_at" db:"ends_at"`
+	IsActive  bool        `json:"is_active"`
+	Stats     *BoostStats `json:"stats,omitempty"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+}
+
+// BoostStats compares a boost's results with what the user would normally get
+// in a window of the same length, based on the week before the boost
+type BoostStats struct {
+	Impressions         int `json:"impressions"` // Swipes received, each a time the profile was seen
+	Likes               int `json:"likes"`       // Likes and super likes received
+	ExpectedImpressions int `json:"expected_impressions"`
+	ExpectedLikes       int `json:"expected_likes"`
+	ExtraImpressions    int `json:"extra_impressions"`
+	ExtraLikes          int `json:"extra_likes"`
+}
+
+// BoostStatus is the user's running boost, if any, and the last finished one
+type BoostStatus struct {
+	Active    *Boost `json:"active"`
+	LastBoost *Boost `json:"last_boost,omitempty"` // Most recent finished boost with final stats
+}
@@ -31,6 +31,31 @@ type Match struct {
 	User2     *User     `json:"user2,omitempty"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	IsActive  bool      `json:"is_active" db:"is_active"`
+
+	// Set while match expiry is enabled and nobody has written yet
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	ExtendedAt *time.Time `json:"extended_at,omitempty" db:"extended_at"`
+
+	// Conversation details, set when listing matches
+	ChatID        string     `json:"chat_id,omitempty"`
+	LastMessageAt *time.Time `json:"last_message_at,omitempty"`
+}
+
+// MatchFilter narrows a match list by whether a conversation has started
+type MatchFilter string
+
+const (
+	MatchFilterAll           MatchFilter = "all"
+	MatchFilterNew           MatchFilter = "new"           // No messages yet
+	MatchFilterConversations MatchFilter = "conversations" // At least one message
+)
+
+// MatchesPage is a page of matches, most recently active first
+type MatchesPage struct {
+	Matches    []Match     `json:"matches"`
+	Total      int         `json:"total"` // All matches for the filter, across pages
+	Filter     MatchFilter `json:"filter"`
+	NextCursor string      `json:"next_cursor,omitempty"` // Empty on the last page
 }
 
 // Chat represents a conversation between matched users
@@ -39,21 +64,117 @@ type Chat struct {
 	MatchID       string     `json:"match_id" db:"match_id"`
 	LastMessage   *Message   `json:"last_message,omitempty"`
 	LastMessageAt *time.Time `json:"last_message_at,omitempty" db:"last_message_at"`
+	LastSeq       int64      `json:"last_seq" db:"last_seq"`               // Sequence number of the newest message, 0 if none
+	LastChangeSeq int64      `json:"last_change_seq" db:"last_change_seq"` // Change sequence number of the newest edit, unsend or reaction change, 0 if none
 	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
 }
 
 // Message represents a chat message
 type Message struct {
-	ID        string    `json:"id" db:"id"`
-	ChatID    string    `json:"chat_id" db:"chat_id"`
-	SenderID  string    `json:"sender_id" db:"sender_id"`
-	Content   string    `json:"content" db:"content"`
-	MessageType string  `json:"message_type" db:"message_type"` // text, image, gif
-	IsRead    bool      `json:"is_read" db:"is_read"`
+	ID              string            `json:"id" db:"id"`
+	ChatID          string            `json:"chat_id" db:"chat_id"`
+	SenderID        string            `json:"sender_id" db:"sender_id"`
+	Seq             int64             `json:"seq" db:"seq"`                                       // Position in the chat, counting up from 1
+	ChangeSeq       int64             `json:"change_seq,omitempty" db:"change_seq"`               // Position of the latest edit, unsend or reaction change in the chat's changes
+	ClientMessageID string            `json:"client_message_id,omitempty" db:"client_message_id"` // Chosen by the sender's client to de-duplicate retries
+	Content         string            `json:"content" db:"content"`
+	MessageType     string            `json:"message_type" db:"message_type"` // text, image, gif
+	IsRead          bool              `json:"is_read" db:"is_read"`
+	DeliveredAt     *time.Time        `json:"delivered_at,omitempty" db:"delivered_at"`
+	ReadAt          *time.Time        `json:"read_at,omitempty" db:"read_at"` // Only set if the reader sends read receipts
+	EditedAt        *time.Time        `json:"edited_at,omitempty" db:"edited_at"`
+	DeletedAt       *time.Time        `json:"deleted_at,omitempty" db:"deleted_at"` // Set on unsent messages, whose content is cleared
+	Reactions       []MessageReaction `json:"reactions,omitempty"`
+	Attachment      *ChatAttachment   `json:"attachment,omitempty"` // Image or GIF sent with the message
+	GIF             *GIF              `json:"gif,omitempty"`        // GIF picked from the GIF provider
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+}
+
+// MessageReaction is a user's emoji reaction to a message. Each user has at
+// most one reaction per message.
+type MessageReaction struct {
+	MessageID string    `json:"message_id" db:"message_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	Emoji     string    `json:"emoji" db:"emoji"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
 
+// ChatAttachment is an image uploaded to a chat. It is private to the two
+// match participants, who get short-lived signed URLs to fetch it.
+type ChatAttachment struct {
+	ID           string    `json:"id"`
+	ChatID       string    `json:"chat_id"`
+	UploaderID   string    `json:"uploader_id"`
+	MessageID    string    `json:"message_id,omitempty"` // Empty until sent in a message
+	S3Key        string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Width        int       `json:"width"`
+	Height       int       `json:"height"`
+	URL          string    `json:"url,omitempty"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+	URLExpiresAt time.Time `json:"url_expires_at,omitempty"` // When the signed URLs stop working
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// GIF is a GIF from the configured GIF provider. Messages store the copy the
+// provider returned when they were sent.
+type GIF struct {
+	ID         string `json:"id"` // Issued by the provider
+	Title      string `json:"title,omitempty"`
+	Rating     string `json:"rating"` // g, pg, pg-13 or r
+	URL        string `json:"url"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	PreviewURL string `json:"preview_url"` // Smaller rendition for pickers and previews
+}
+
+// GIFPage is a page of GIF search or trending results
+type GIFPage struct {
+	GIFs       []GIF `json:"gifs"`
+	Offset     int   `json:"offset"`
+	NextOffset int   `json:"next_offset,omitempty"` // Zero when there are no more results
+}
+
+// MessageEdit is an earlier version of an edited message
+type MessageEdit struct {
+	ID        string    `json:"id" db:"id"`
+	MessageID string    `json:"message_id" db:"message_id"`
+	Content   string    `json:"content" db:"content"`     // Content before the edit
+	EditedAt  time.Time `json:"edited_at" db:"edited_at"` // When it was replaced
+}
+
+// EditMessageRequest replaces the content of a text message
+type EditMessageRequest struct {
+	Content string `json:"content" validate:"required,max=500"`
+}
+
+// ReactionRequest sets the caller's reaction to a message
+type ReactionRequest struct {
+	Emoji string `json:"emoji" validate:"required,max=32"`
+}
+
+// MarkReadRequest marks a chat read up to and including a message
+type MarkReadRequest struct {
+	MessageID string `json:"message_id" validate:"required,uuid"`
+}
+
+// ChatReadState is the result of marking a chat read
+type ChatReadState struct {
+	ChatID            string    `json:"chat_id"`
+	LastReadMessageID string    `json:"last_read_message_id"`
+	MarkedRead        int       `json:"marked_read"`   // Messages newly marked read
+	ReceiptsSent      bool      `json:"receipts_sent"` // False when the user has read receipts turned off
+	ReadAt            time.Time `json:"read_at"`
+}
+
+// UnmatchRequest represents the optional request body for removing a match
+type UnmatchRequest struct {
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=500"`
+}
+
 // SwipeRequest represents the request body for swiping
 type SwipeRequest struct {
 	TargetID string      `json:"target_id" validate:"required"`
@@ -62,8 +183,11 @@ type SwipeRequest struct {
 
 // MessageRequest represents the request body for sending a message
 type MessageRequest struct {
-	Content     string `json:"content" validate:"required,max=500"`
-	MessageType string `json:"message_type,omitempty" validate:"oneof=text image gif"`
+	Content         string `json:"content" validate:"required_without_all=AttachmentID GIFID,max=500"` // Optional caption when sending an attachment or GIF
+	MessageType     string `json:"message_type,omitempty" validate:"omitempty,oneof=text image gif"`
+	ClientMessageID string `json:"client_message_id,omitempty" validate:"omitempty,max=64"`                 // Retrying with the same ID never sends twice
+	AttachmentID    string `json:"attachment_id,omitempty" validate:"omitempty,uuid"`                       // Uploaded through POST /chats/{chatID}/attachments; sets the message type
+	GIFID           string `json:"gif_id,omitempty" validate:"omitempty,max=64,excluded_with=AttachmentID"` // From GIF search; sends a gif message
 }
 
 // GetOtherUser returns the other user in a match (not the current user)
@@ -72,4 +196,70 @@ func (m *Match) GetOtherUser(currentUserID string) *User {
 		return m.User2
 	}
 	return m.User1
-}
\ No newline at end of file
+}
+// ReceivedLike is a pending like or super like from another user. Users
+// without the see-who-likes-you entitlement get a blurred preview without
+// the liker's profile.
+type ReceivedLike struct {
+	ID          string      `json:"id"` // ID of the liker's swipe
+	Action      SwipeAction `json:"action"`
+	IsSuperLike bool        `json:"is_super_like"`
+	Blurred     bool        `json:"blurred"`
+	User        *User       `json:"user,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// ReceivedLikesPage is a page of the likes inbox
+type ReceivedLikesPage struct {
+	Likes      []ReceivedLike `json:"likes"`
+	Total      int            `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"` // Empty on the last page
+	CanSee     bool           `json:"can_see"`               // Whether profiles are revealed
+}
+
+// SubscriptionTier represents a user's paid plan
+type SubscriptionTier string
+
+const (
+	TierFree    SubscriptionTier = "free"
+	TierPlus    SubscriptionTier = "plus"
+	TierPremium SubscriptionTier = "premium"
+)
+
+// UnlimitedQuota marks a daily allowance without a limit
+const UnlimitedQuota = -1
+
+// Entitlements are the daily allowances granted by a subscription tier
+type Entitlements struct {
+	Tier            SubscriptionTier `json:"tier"`
+	DailyLikes      int              `json:"daily_likes"`       // -1 means unlimited
+	DailySuperLikes int              `json:"daily_super_likes"` // -1 means unlimited
+	DailyRewinds    int              `json:"daily_rewinds"`     // -1 means unlimited
+	SeeWhoLikesYou  bool             `json:"see_who_likes_you"` // Full profiles in the likes inbox
+}
+
+// SwipeQuota reports a user's remaining swipes for the current day
+type SwipeQuota struct {
+	Tier                SubscriptionTier `json:"tier"`
+	LikesLimit          int              `json:"likes_limit"` // -1 means unlimited
+	LikesUsed           int              `json:"likes_used"`
+	LikesRemaining      int              `json:"likes_remaining"` // -1 means unlimited
+	SuperLikesLimit     int              `json:"super_likes_limit"`
+	SuperLikesUsed      int              `json:"super_likes_used"`
+	SuperLikesRemaining int              `json:"super_likes_remaining"`
+	RewindsLimit        int              `json:"rewinds_limit"`
+	RewindsUsed         int              `json:"rewinds_used"`
+	RewindsRemaining    int              `json:"rewinds_remaining"`
+	Timezone            string           `json:"timezone"`
+	ResetsAt            time.Time        `json:"resets_at"` // Next local midnight
+}
+
+// QuotaExceededResponse is returned with 429 when a daily allowance is used up
+type QuotaExceededResponse struct {
+	Success    bool        `json:"success"`
+	Error      string      `json:"error"`
+	Code       int         `json:"code"`
+	Action     SwipeAction `json:"action"`
+	RetryAfter int64       `json:"retry_after"` // Seconds until the quota resets
+	Quota      *SwipeQuota `json:"quota"`
+}
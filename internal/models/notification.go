@@ -26,6 +26,7 @@ const (
 	// System notifications
 	NotificationAccountVerified NotificationType = "account_verified"
 	NotificationAccountWarning  NotificationType = "account_warning"
+	NotificationDataExportReady NotificationType = "data_export_ready"
 	NotificationPromotional     NotificationType = "promotional"
 )
 
@@ -154,6 +155,9 @@ func GetNotificationTemplate(notifType NotificationType, data map[string]interfa
 		}
 		return "Match Expiring Soon", "One of your matches expires soon. Send a message!"
 	
+	case NotificationDataExportReady:
+		return "Your Data Is Ready", "The copy of your data you requested is ready to download."
+	
 	default:
 		return "Notification", "You have a new notification"
 	}
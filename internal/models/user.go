@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"matching-api/pkg/geo"
 )
 
 // User represents a user in the dating app
@@ -11,23 +13,39 @@ type User struct {
 	Password    string     `json:"-" db:"password"` // Never return password in JSON
 	FirstName   string     `json:"first_name" db:"first_name"`
 	LastName    string     `json:"last_name" db:"last_name"`
-	Age         int        `json:"age" db:"age"`
+	Age         int        `json:"age,omitempty" db:"age"` // Omitted on public profiles that hide their age
 	Bio         string     `json:"bio" db:"bio"`
 	Gender      string     `json:"gender" db:"gender"`
-	Location    *Location  `json:"location" db:"location"`
+	Location    *Location  `json:"location,omitempty" db:"location"` // Never included in public profiles
+	Distance    string     `json:"distance,omitempty"`               // Distance bucket relative to the viewer
 	Photos      []Photo    `json:"photos,omitempty"`
 	Preferences *UserPrefs `json:"preferences,omitempty"`
 	IsVerified  bool       `json:"is_verified" db:"is_verified"`
 	IsActive    bool       `json:"is_active" db:"is_active"`
+	Role        Role       `json:"role,omitempty" db:"role"`
 	LastSeen    *time.Time `json:"last_seen,omitempty" db:"last_seen"`
-	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Moderation state, only exposed through admin endpoints
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty" db:"suspended_at"`
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty" db:"suspended_until"`
+	SuspensionReason string     `json:"suspension_reason,omitempty" db:"suspension_reason"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsSuspended reports whether the user is currently suspended
+func (u *User) IsSuspended() bool {
+	if u.SuspendedAt == nil {
+		return false
+	}
+	return u.SuspendedUntil == nil || u.SuspendedUntil.After(time.Now())
 }
 
 // Location represents geographical location
 type Location struct {
-	Latitude  float64 `json:"latitude" db:"latitude"`
-	Longitude float64 `json:"longitude" db:"longitude"`
+	Latitude  float64 `json:"latitude" db:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" db:"longitude" validate:"min=-180,max=180"`
 	City      string  `json:"city,omitempty" db:"city"`
 	State     string  `json:"state,omitempty" db:"state"`
 	Country   string  `json:"country,omitempty" db:"country"`
@@ -44,32 +62,65 @@ type Photo struct {
 
 // UserPrefs represents user matching preferences
 type UserPrefs struct {
-	ID              string   `json:"id" db:"id"`
-	UserID          string   `json:"user_id" db:"user_id"`
-	AgeMin          int      `json:"age_min" db:"age_min"`
-	AgeMax          int      `json:"age_max" db:"age_max"`
-	MaxDistance     int      `json:"max_distance" db:"max_distance"` // in kilometers
-	InterestedIn    []string `json:"interested_in" db:"interested_in"` // genders
-	ShowMe          string   `json:"show_me" db:"show_me"`
-	OnlyVerified    bool     `json:"only_verified" db:"only_verified"`
-	HideDistance    bool     `json:"hide_distance" db:"hide_distance"`
-	HideAge         bool     `json:"hide_age" db:"hide_age"`
-	CreatedAt       time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+	ID               string    `json:"id" db:"id"`
+	UserID           string    `json:"user_id" db:"user_id"`
+	AgeMin           int       `json:"age_min" db:"age_min"`
+	AgeMax           int       `json:"age_max" db:"age_max"`
+	MaxDistance      int       `json:"max_distance" db:"max_distance"`   // in kilometers
+	InterestedIn     []string  `json:"interested_in" db:"interested_in"` // genders
+	ShowMe           string    `json:"show_me" db:"show_me"`
+	OnlyVerified     bool      `json:"only_verified" db:"only_verified"`
+	HideDistance     bool      `json:"hide_distance" db:"hide_distance"`
+	HideAge          bool      `json:"hide_age" db:"hide_age"`
+	SendReadReceipts bool      `json:"send_read_receipts" db:"send_read_receipts"` // Let senders see when their messages were read
+	CreatedAt        time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" db:"updated_at"`
 }
 
-// Public returns a user without sensitive information
+// Public returns a user without sensitive information, as shown to other
+// users. Coordinates are never included, and the age is left out when the
+// user's preferences hide it.
 func (u *User) Public() *User {
-	return &User{
+	public := &User{
 		ID:         u.ID,
 		FirstName:  u.FirstName,
 		Age:        u.Age,
 		Bio:        u.Bio,
 		Gender:     u.Gender,
-		Location:   u.Location,
 		Photos:     u.Photos,
 		IsVerified: u.IsVerified,
 		IsActive:   u.IsActive,
 		LastSeen:   u.LastSeen,
 	}
-}
\ No newline at end of file
+	if u.Preferences != nil && u.Preferences.HideAge {
+		public.Age = 0
+	}
+	return public
+}
+
+// Own returns the user's full profile as shown to the user themselves, without
+// the password hash or moderation state
+func (u *User) Own() *User {
+	own := *u
+	own.Password = ""
+	own.SuspendedAt = nil
+	own.SuspendedUntil = nil
+	own.SuspensionReason = ""
+	return &own
+}
+
+// PublicFor returns the public profile as seen by a viewer at the given
+// location, with a rounded distance unless the user hides it
+func (u *User) PublicFor(viewer *Location) *User {
+	public := u.Public()
+	if viewer == nil || u.Location == nil {
+		return public
+	}
+	if u.Preferences != nil && u.Preferences.HideDistance {
+		return public
+	}
+
+	km := geo.DistanceKm(viewer.Latitude, viewer.Longitude, u.Location.Latitude, u.Location.Longitude)
+	public.Distance = geo.DistanceBucket(km)
+	return public
+}
@@ -0,0 +1,398 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+	"matching-api/pkg/auth"
+	"matching-api/pkg/cache"
+	"matching-api/pkg/services"
+)
+
+// DefaultDeletionGracePeriod is how long a deletion request can be cancelled
+// before the account is purged
+const DefaultDeletionGracePeriod = 30 * 24 * time.Hour
+
+// AccountDeletionService schedules account deletions and purges accounts once
+// their grace period has passed
+type AccountDeletionService struct {
+	s3          *services.S3Service
+	redis       *services.RedisService
+	cache       cache.Cache
+	revocations auth.RevocationStore
+	gracePeriod time.Duration
+}
+
+// NewAccountDeletionService creates a new account deletion service.
+// The grace period can be overridden with ACCOUNT_DELETION_GRACE_DAYS.
+func NewAccountDeletionService(s3Service *services.S3Service, redisService *services.RedisService, cacheStore cache.Cache, revocations auth.RevocationStore) *AccountDeletionService {
+	gracePeriod := DefaultDeletionGracePeriod
+	if days, err := strconv.Atoi(os.Getenv("ACCOUNT_DELETION_GRACE_DAYS")); err == nil && days >= 0 {
+		gracePeriod = time.Duration(days) * 24 * time.Hour
+	}
+
+	return &AccountDeletionService{
+		s3:          s3Service,
+		redis:       redisService,
+		cache:       cacheStore,
+		revocations: revocations,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// ScheduleDeletion marks an account for deletion after the grace period and
+// hides it from other users in the meantime
+func (ds *AccountDeletionService) ScheduleDeletion(userID, reason string) (*models.AccountDeletionStatus, error) {
+	if database.DB == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	now := time.Now()
+	status := &models.AccountDeletionStatus{
+		RequestedAt:  now,
+		ScheduledFor: now.Add(ds.gracePeriod),
+	}
+
+	_, err := database.DB.Exec(`
+		UPDATE users
+		SET deletion_requested_at = $1, deletion_scheduled_for = $2, deletion_reason = NULLIF($3, ''),
+		    is_active = false, updated_at = NOW()
+		WHERE id = $4
+	`, status.RequestedAt, status.ScheduledFor, reason, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+
+	ds.clearCache(userID)
+	return status, nil
+}
+
+// CancelDeletion cancels a pending deletion. It returns false if none was pending.
+func (ds *AccountDeletionService) CancelDeletion(userID string) (bool, error) {
+	if database.DB == nil {
+		return false, fmt.Errorf("database not available")
+	}
+
+	result, err := database.DB.Exec(`
+		UPDATE users
+		SET deletion_requested_at = NULL, deletion_scheduled_for = NULL, deletion_reason = NULL,
+		    is_active = true, updated_at = NOW()
+		WHERE id = $1 AND deletion_scheduled_for IS NOT NULL
+	`, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel account deletion: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	if rowsAffected == 0 {
+		return false, nil
+	}
+
+	ds.clearCache(userID)
+	return true, nil
+}
+
+// GetDeletionStatus returns the pending deletion for a user, or nil if none
+func (ds *AccountDeletionService) GetDeletionStatus(userID string) (*models.AccountDeletionStatus, error) {
+	if database.DB == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var requestedAt, scheduledFor sql.NullTime
+	err := database.DB.QueryRow(`
+		SELECT deletion_requested_at, deletion_scheduled_for FROM users WHERE id = $1
+	`, userID).Scan(&requestedAt, &scheduledFor)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account deletion status: %w", err)
+	}
+	if !scheduledFor.Valid {
+		return nil, nil
+	}
+
+	return &models.AccountDeletionStatus{
+		RequestedAt:  requestedAt.Time,
+		ScheduledFor: scheduledFor.Time,
+	}, nil
+}
+
+// Start purges due accounts every interval until the context is cancelled
+func (ds *AccountDeletionService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if database.DB == nil {
+				continue
+			}
+			if purged, err := ds.PurgeDueAccounts(ctx); err != nil {
+				log.Printf("Account deletion purge failed: %v", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d deleted accounts", purged)
+			}
+		}
+	}
+}
+
+// PurgeDueAccounts purges every account whose grace period has passed
+func (ds *AccountDeletionService) PurgeDueAccounts(ctx context.Context) (int, error) {
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id FROM users
+		WHERE deletion_scheduled_for IS NOT NULL AND deletion_scheduled_for <= NOW()
+		ORDER BY deletion_scheduled_for ASC
+		LIMIT 100
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query accounts due for deletion: %w", err)
+	}
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			log.Printf("Error scanning account due for deletion: %v", err)
+			continue
+		}
+		userIDs = append(userIDs, userID)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+
+	purged := 0
+	for _, userID := range userIDs {
+		if _, err := ds.PurgeUser(ctx, userID); err != nil {
+			// Leave the account scheduled so the next run retries it
+			log.Printf("Failed to purge account %s: %v", userID, err)
+			continue
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// PurgeUser permanently removes an account: storage objects are deleted,
+// analytics events are anonymized, the user row is deleted (cascading to all
+// owned rows), Redis state is cleared and a deletion receipt is recorded.
+func (ds *AccountDeletionService) PurgeUser(ctx context.Context, userID string) (*models.AccountDeletionReceipt, error) {
+	var email string
+	var reason sql.NullString
+	var requestedAt sql.NullTime
+	err := database.DB.QueryRowContext(ctx, `
+		SELECT email, deletion_reason, deletion_requested_at FROM users WHERE id = $1
+	`, userID).Scan(&email, &reason, &requestedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+
+	receipt := &models.AccountDeletionReceipt{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		EmailHash:   hashEmail(email),
+		Reason:      reason.String,
+		RequestedAt: time.Now(),
+	}
+	if requestedAt.Valid {
+		receipt.RequestedAt = requestedAt.Time
+	}
+
+	// Storage objects go first: once the rows are gone their keys are lost
+	deleted, err := ds.deleteStorageObjects(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	receipt.StorageObjectsDeleted = deleted
+
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start purge transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back purge transaction: %v", err)
+		}
+	}()
+
+	// Keep aggregate analytics but detach them from the person. This must run
+	// before the user row is deleted, which would otherwise cascade to them.
+	result, err := tx.ExecContext(ctx, `
+		UPDATE analytics_events
+		SET user_id = NULL, session_id = NULL, ip_address = NULL, user_agent = NULL
+		WHERE user_id = $1
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to anonymize analytics events: %w", err)
+	}
+	receipt.AnalyticsEventsAnonymized, _ = result.RowsAffected()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	receipt.CompletedAt = time.Now()
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO account_deletion_receipts
+			(id, user_id, email_hash, reason, requested_at, completed_at, storage_objects_deleted, analytics_events_anonymized)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8)
+	`, receipt.ID, receipt.UserID, receipt.EmailHash, receipt.Reason, receipt.RequestedAt,
+		receipt.CompletedAt, receipt.StorageObjectsDeleted, receipt.AnalyticsEventsAnonymized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record deletion receipt: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit purge: %w", err)
+	}
+
+	ds.clearSessions(userID)
+	ds.clearCache(userID)
+
+	log.Printf("Account %s purged (receipt %s)", userID, receipt.ID)
+	return receipt, nil
+}
+
+// storageKeys returns every storage object key owned by the user
+func (ds *AccountDeletionService) storageKeys(ctx context.Context, userID string) ([]string, error) {
+	seen := make(map[string]bool)
+	var keys []string
+	add := func(key string) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+
+	rows, err := database.DB.QueryContext(ctx, `SELECT s3_key FROM images WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query image keys: %w", err)
+	}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			log.Printf("Error scanning image key: %v", err)
+			continue
+		}
+		add(key)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+
+	rows, err = database.DB.QueryContext(ctx, `SELECT s3_key FROM data_exports WHERE user_id = $1 AND s3_key IS NOT NULL`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data export keys: %w", err)
+	}
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			log.Printf("Error scanning data export key: %v", err)
+			continue
+		}
+		add(key)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+
+	rows, err = database.DB.QueryContext(ctx, `SELECT s3_key, thumbnail_key FROM chat_attachments WHERE uploader_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chat attachment keys: %w", err)
+	}
+	for rows.Next() {
+		var key, thumbnailKey string
+		if err := rows.Scan(&key, &thumbnailKey); err != nil {
+			log.Printf("Error scanning chat attachment key: %v", err)
+			continue
+		}
+		add(key)
+		add(thumbnailKey)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+
+	// Also sweep the user's prefix to catch objects without a row, such as
+	// presigned uploads that were never confirmed
+	objects, err := ds.s3.ListUserImages(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		add(object.Key)
+	}
+
+	return keys, nil
+}
+
+// deleteStorageObjects deletes all of the user's storage objects, including
+// generated thumbnails, and returns how many keys were deleted
+func (ds *AccountDeletionService) deleteStorageObjects(ctx context.Context, userID string) (int, error) {
+	if ds.s3 == nil {
+		return 0, nil
+	}
+
+	keys, err := ds.storageKeys(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Thumbnails live next to the original with a _thumb suffix
+	for _, key := range keys {
+		if strings.HasPrefix(key, "exports/") {
+			continue
+		}
+		if dot := strings.LastIndex(key, "."); dot > 0 && !strings.Contains(key, "_thumb.") {
+			keys = append(keys, key[:dot]+"_thumb"+key[dot:])
+		}
+	}
+
+	if err := ds.s3.DeleteImages(ctx, keys); err != nil {
+		return 0, err
+	}
+	return len(keys), nil
+}
+
+// clearSessions removes refresh sessions and revokes outstanding access tokens
+func (ds *AccountDeletionService) clearSessions(userID string) {
+	if ds.revocations != nil {
+		if err := ds.revocations.RevokeUserTokens(userID, time.Now()); err != nil {
+			log.Printf("Failed to revoke access tokens for %s: %v", userID, err)
+		}
+	}
+	if ds.redis != nil {
+		if _, err := ds.redis.DeleteUserSessions(userID); err != nil {
+			log.Printf("Failed to delete sessions for %s: %v", userID, err)
+		}
+	}
+}
+
+// clearCache removes cached profile and match data for the user
+func (ds *AccountDeletionService) clearCache(userID string) {
+	if err := cache.InvalidateUser(ds.cache, userID); err != nil {
+		log.Printf("Failed to invalidate cache for %s: %v", userID, err)
+	}
+}
+
+// hashEmail returns a hex SHA-256 of the normalized email for audit records
+func hashEmail(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
@@ -59,6 +59,17 @@ func (as *AnalyticsService) TrackUserLogin(userID string, r *http.Request) error
 	return as.TrackEvent(&userID, models.EventUserLogin, data, r)
 }
 
+// TrackAccountLocked tracks an account lockout after repeated failed logins.
+// The user may be unknown, so only a hash of the attempted email is recorded.
+func (as *AnalyticsService) TrackAccountLocked(emailHash string, failedAttempts int, lockedFor time.Duration, r *http.Request) error {
+	data := map[string]interface{}{
+		"email_hash":         emailHash,
+		"failed_attempts":    failedAttempts,
+		"locked_for_seconds": int(lockedFor.Seconds()),
+	}
+	return as.TrackEvent(nil, models.EventAccountLocked, data, r)
+}
+
 // TrackUserRegistration tracks user registration
 func (as *AnalyticsService) TrackUserRegistration(userID string, registrationMethod string, r *http.Request) error {
 	data := map[string]interface{}{
@@ -106,6 +117,18 @@ func (as *AnalyticsService) TrackMatch(user1ID, user2ID string, r *http.Request)
 	return nil
 }
 
+// TrackUnmatch tracks a match being removed by one of its users
+func (as *AnalyticsService) TrackUnmatch(userID, otherUserID, matchID, reason string, r *http.Request) error {
+	data := map[string]interface{}{
+		"match_id":          matchID,
+		"unmatched_user_id": otherUserID,
+	}
+	if reason != "" {
+		data["reason"] = reason
+	}
+	return as.TrackEvent(&userID, models.EventMatchDeleted, data, r)
+}
+
 // TrackMessage tracks message events
 func (as *AnalyticsService) TrackMessage(senderID, recipientID, chatID string, messageType string, r *http.Request) error {
 	data := map[string]interface{}{
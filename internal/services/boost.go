@@ -0,0 +1,218 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+)
+
+// DefaultBoostDuration is how long a boost lasts unless BOOST_DURATION_MINUTES is set
+const DefaultBoostDuration = 30 * time.Minute
+
+// boostBaselineWindow is the period before a boost used to estimate what the
+// user would have received without it
+const boostBaselineWindow = 7 * 24 * time.Hour
+
+// BoostService activates profile boosts, tells discovery which candidates are
+// boosted and reports how a boost performed
+type BoostService struct {
+	duration time.Duration
+}
+
+// NewBoostService creates a new boost service
+func NewBoostService() *BoostService {
+	duration := DefaultBoostDuration
+	if minutes, err := strconv.Atoi(os.Getenv("BOOST_DURATION_MINUTES")); err == nil && minutes > 0 {
+		duration = time.Duration(minutes) * time.Minute
+	}
+
+	return &BoostService{duration: duration}
+}
+
+// Activate starts a boost for the user. If one is already running it is
+// returned instead, with created set to false.
+func (bs *BoostService) Activate(userID string) (boost *models.Boost, created bool, err error) {
+	if database.DB == nil {
+		return nil, false, fmt.Errorf("database not available")
+	}
+
+	now := time.Now()
+	boost = &models.Boost{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		StartedAt: now,
+		EndsAt:    now.Add(bs.duration),
+		IsActive:  true,
+		CreatedAt: now,
+	}
+
+	tx, err := database.DB.Begin()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to start boost transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back boost activation: %v", err)
+		}
+	}()
+
+	// Lock the user row so concurrent activations run one after another; the
+	// NOT EXISTS check alone can't see a boost another transaction is inserting
+	var locked string
+	if err := tx.QueryRow(`SELECT id FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&locked); err != nil {
+		return nil, false, fmt.Errorf("failed to lock user for boost: %w", err)
+	}
+
+	// Only insert when no boost is running, so concurrent requests start one boost
+	result, err := tx.Exec(`
+		INSERT INTO boosts (id, user_id, started_at, ends_at, created_at)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (SELECT 1 FROM boosts WHERE user_id = $2 AND ends_at > $3)
+	`, boost.ID, boost.UserID, boost.StartedAt, boost.EndsAt, boost.CreatedAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create boost: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to commit boost: %w", err)
+	}
+
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		active, err := bs.GetActive(userID)
+		if err != nil {
+			return nil, false, err
+		}
+		if active != nil {
+			return active, false, nil
+		}
+	}
+
+	boost.Stats = &models.BoostStats{}
+	return boost, true, nil
+}
+
+// GetActive returns the user's running boost with its stats so far, or nil
+func (bs *BoostService) GetActive(userID string) (*models.Boost, error) {
+	return bs.loadBoost(`
+		SELECT id, user_id, started_at, ends_at, created_at
+		FROM boosts WHERE user_id = $1 AND ends_at > NOW()
+		ORDER BY started_at DESC LIMIT 1
+	`, userID)
+}
+
+// GetLastFinished returns the user's most recent finished boost with its final
+// stats, or nil
+func (bs *BoostService) GetLastFinished(userID string) (*models.Boost, error) {
+	return bs.loadBoost(`
+		SELECT id, user_id, started_at, ends_at, created_at
+		FROM boosts WHERE user_id = $1 AND ends_at <= NOW()
+		ORDER BY ends_at DESC LIMIT 1
+	`, userID)
+}
+
+// ActiveBoosts returns the boost ID for each of the given users that has a
+// boost running
+func (bs *BoostService) ActiveBoosts(userIDs []string) (map[string]string, error) {
+	boosted := make(map[string]string)
+	if database.DB == nil || len(userIDs) == 0 {
+		return boosted, nil
+	}
+
+	rows, err := database.DB.Query(`
+		SELECT DISTINCT ON (user_id) user_id, id
+		FROM boosts
+		WHERE user_id = ANY($1) AND started_at <= NOW() AND ends_at > NOW()
+		ORDER BY user_id, started_at DESC
+	`, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load active boosts: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	for rows.Next() {
+		var userID, boostID string
+		if err := rows.Scan(&userID, &boostID); err != nil {
+			return nil, err
+		}
+		boosted[userID] = boostID
+	}
+
+	return boosted, rows.Err()
+}
+
+// loadBoost scans a single boost and computes its stats. It returns nil if the
+// query has no rows.
+func (bs *BoostService) loadBoost(query string, args ...interface{}) (*models.Boost, error) {
+	if database.DB == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	var boost models.Boost
+	err := database.DB.QueryRow(query, args...).Scan(
+		&boost.ID, &boost.UserID, &boost.StartedAt, &boost.EndsAt, &boost.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load boost: %w", err)
+	}
+	boost.IsActive = boost.EndsAt.After(time.Now())
+
+	stats, err := bs.stats(&boost)
+	if err != nil {
+		return nil, err
+	}
+	boost.Stats = stats
+
+	return &boost, nil
+}
+
+// stats compares the boost with the week before it. Every swipe received
+// implies the profile was shown, so swipes received count as impressions in
+// both periods. Decks are cached, so counting deck appearances would miss
+// most of them.
+func (bs *BoostService) stats(boost *models.Boost) (*models.BoostStats, error) {
+	stats := &models.BoostStats{}
+
+	// Stats so far for a running boost
+	end := boost.EndsAt
+	if now := time.Now(); now.Before(end) {
+		end = now
+	}
+
+	var baselineSwipes, baselineLikes int
+	err := database.DB.QueryRow(`
+		SELECT
+			COUNT(*) FILTER (WHERE created_at >= $2 AND created_at < $3),
+			COUNT(*) FILTER (WHERE created_at >= $2 AND created_at < $3 AND action IN ('right', 'super')),
+			COUNT(*) FILTER (WHERE created_at >= $4 AND created_at < $2),
+			COUNT(*) FILTER (WHERE created_at >= $4 AND created_at < $2 AND action IN ('right', 'super'))
+		FROM swipes
+		WHERE target_id = $1 AND created_at >= $4 AND created_at < $3
+	`, boost.UserID, boost.StartedAt, end, boost.StartedAt.Add(-boostBaselineWindow)).Scan(
+		&stats.Impressions, &stats.Likes, &baselineSwipes, &baselineLikes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute boost stats: %w", err)
+	}
+
+	// Scale the week's numbers down to the length of the boost so far
+	scale := float64(end.Sub(boost.StartedAt)) / float64(boostBaselineWindow)
+	stats.ExpectedImpressions = int(math.Round(float64(baselineSwipes) * scale))
+	stats.ExpectedLikes = int(math.Round(float64(baselineLikes) * scale))
+	stats.ExtraImpressions = max(stats.Impressions-stats.ExpectedImpressions, 0)
+	stats.ExtraLikes = max(stats.Likes-stats.ExpectedLikes, 0)
+
+	return stats, nil
+}
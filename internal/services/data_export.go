@@ -0,0 +1,527 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+	"matching-api/pkg/services"
+)
+
+// DefaultExportRetention is how long a finished export stays downloadable
+const DefaultExportRetention = 7 * 24 * time.Hour
+
+// exportLinkExpiry is how long each generated download link stays valid
+const exportLinkExpiry = time.Hour
+
+// DataExportService assembles a ZIP archive of everything stored about a user
+// in the background and hands out time-limited links to download it
+type DataExportService struct {
+	s3            *services.S3Service
+	notifications *NotificationService
+	queue         chan string
+	retention     time.Duration
+}
+
+// NewDataExportService creates a new data export service
+func NewDataExportService(s3Service *services.S3Service, notifications *NotificationService) *DataExportService {
+	return &DataExportService{
+		s3:            s3Service,
+		notifications: notifications,
+		queue:         make(chan string, 100),
+		retention:     DefaultExportRetention,
+	}
+}
+
+// RequestExport queues a new export for the user. If one is already queued or
+// running it is returned instead, with created set to false.
+func (es *DataExportService) RequestExport(userID string) (export *models.DataExport, created bool, err error) {
+	if database.DB == nil {
+		return nil, false, fmt.Errorf("database not available")
+	}
+
+	existing, err := es.scanExport(database.DB.QueryRow(`
+		SELECT id, user_id, status, s3_key, size, error, created_at, completed_at, expires_at
+		FROM data_exports
+		WHERE user_id = $1 AND status IN ('pending', 'processing')
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, userID))
+	if err == nil {
+		return existing, false, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, fmt.Errorf("failed to check pending exports: %w", err)
+	}
+
+	export = &models.DataExport{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Status:    models.DataExportPending,
+		CreatedAt: time.Now(),
+	}
+	_, err = database.DB.Exec(`
+		INSERT INTO data_exports (id, user_id, status, created_at) VALUES ($1, $2, $3, $4)
+	`, export.ID, export.UserID, export.Status, export.CreatedAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	es.enqueue(export.ID)
+	return export, true, nil
+}
+
+// GetExport returns one of the user's exports, with a fresh download link when
+// it has completed and not yet expired. It returns nil if no such export exists.
+func (es *DataExportService) GetExport(ctx context.Context, userID, exportID string) (*models.DataExport, error) {
+	if database.DB == nil {
+		return nil, fmt.Errorf("database not available")
+	}
+
+	export, err := es.scanExport(database.DB.QueryRowContext(ctx, `
+		SELECT id, user_id, status, s3_key, size, error, created_at, completed_at, expires_at
+		FROM data_exports
+		WHERE id = $1 AND user_id = $2
+	`, exportID, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	if export.Status != models.DataExportCompleted || export.S3Key == "" || es.s3 == nil {
+		return export, nil
+	}
+	if export.ExpiresAt != nil && time.Now().After(*export.ExpiresAt) {
+		return export, nil
+	}
+
+	linkExpiry := exportLinkExpiry
+	if export.ExpiresAt != nil {
+		if remaining := time.Until(*export.ExpiresAt); remaining < linkExpiry {
+			linkExpiry = remaining
+		}
+	}
+	url, err := es.s3.GeneratePresignedDownloadURL(ctx, export.S3Key, linkExpiry)
+	if err != nil {
+		return nil, err
+	}
+	export.DownloadURL = url
+
+	return export, nil
+}
+
+// Start processes queued exports until the context is cancelled. Every
+// interval it re-queues exports that were never picked up, such as those left
+// behind by a restart, and deletes archives past their retention period.
+func (es *DataExportService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	es.sweep(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case exportID := <-es.queue:
+			if err := es.process(ctx, exportID); err != nil {
+				log.Printf("Data export %s failed: %v", exportID, err)
+			}
+		case <-ticker.C:
+			es.sweep(ctx)
+		}
+	}
+}
+
+// enqueue hands an export to the worker. When the queue is full the export
+// stays pending and is picked up by the next sweep.
+func (es *DataExportService) enqueue(exportID string) {
+	select {
+	case es.queue <- exportID:
+	default:
+		log.Printf("Data export queue full, deferring export %s", exportID)
+	}
+}
+
+// sweep re-queues unfinished exports and removes expired archives
+func (es *DataExportService) sweep(ctx context.Context) {
+	if database.DB == nil {
+		return
+	}
+
+	// Exports marked processing when the server stopped are restarted as well;
+	// a running export is never swept because the worker handles one at a time
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id FROM data_exports
+		WHERE status IN ('pending', 'processing')
+		ORDER BY created_at ASC
+		LIMIT 100
+	`)
+	if err != nil {
+		log.Printf("Failed to query pending data exports: %v", err)
+		return
+	}
+	var pending []string
+	for rows.Next() {
+		var exportID string
+		if err := rows.Scan(&exportID); err != nil {
+			log.Printf("Error scanning pending data export: %v", err)
+			continue
+		}
+		pending = append(pending, exportID)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+	for _, exportID := range pending {
+		es.enqueue(exportID)
+	}
+
+	if err := es.deleteExpired(ctx); err != nil {
+		log.Printf("Failed to delete expired data exports: %v", err)
+	}
+}
+
+// deleteExpired removes archives past their retention period from storage and
+// clears their keys so the links can no longer be generated
+func (es *DataExportService) deleteExpired(ctx context.Context) error {
+	if es.s3 == nil {
+		return nil
+	}
+
+	rows, err := database.DB.QueryContext(ctx, `
+		SELECT id, s3_key FROM data_exports
+		WHERE s3_key IS NOT NULL AND expires_at <= NOW()
+		LIMIT 1000
+	`)
+	if err != nil {
+		return err
+	}
+	var ids, keys []string
+	for rows.Next() {
+		var id, key string
+		if err := rows.Scan(&id, &key); err != nil {
+			log.Printf("Error scanning expired data export: %v", err)
+			continue
+		}
+		ids = append(ids, id)
+		keys = append(keys, key)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := es.s3.DeleteImages(ctx, keys); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if _, err := database.DB.ExecContext(ctx, `UPDATE data_exports SET s3_key = NULL WHERE id = $1`, id); err != nil {
+			log.Printf("Failed to clear key of expired data export %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// process builds, uploads and announces a single export
+func (es *DataExportService) process(ctx context.Context, exportID string) error {
+	var userID string
+	result, err := database.DB.ExecContext(ctx, `
+		UPDATE data_exports SET status = 'processing' WHERE id = $1 AND status IN ('pending', 'processing')
+	`, exportID)
+	if err != nil {
+		return fmt.Errorf("failed to claim export: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return nil // Already finished, e.g. queued twice by a sweep
+	}
+	if err := database.DB.QueryRowContext(ctx, `SELECT user_id FROM data_exports WHERE id = $1`, exportID).Scan(&userID); err != nil {
+		return fmt.Errorf("failed to load export: %w", err)
+	}
+
+	key, size, err := es.buildAndUpload(ctx, userID, exportID)
+	if err != nil {
+		// The cause is logged by the caller; users only see a generic message
+		if _, dbErr := database.DB.ExecContext(ctx, `
+			UPDATE data_exports SET status = 'failed', error = $1, completed_at = NOW() WHERE id = $2
+		`, "Export could not be completed, please request a new one", exportID); dbErr != nil {
+			log.Printf("Failed to mark data export %s as failed: %v", exportID, dbErr)
+		}
+		return err
+	}
+
+	completedAt := time.Now()
+	_, err = database.DB.ExecContext(ctx, `
+		UPDATE data_exports
+		SET status = 'completed', s3_key = $1, size = $2, completed_at = $3, expires_at = $4
+		WHERE id = $5
+	`, key, size, completedAt, completedAt.Add(es.retention), exportID)
+	if err != nil {
+		return fmt.Errorf("failed to complete export: %w", err)
+	}
+
+	if es.notifications != nil {
+		if err := es.notifications.SendDataExportNotification(userID, exportID); err != nil {
+			log.Printf("Failed to send data export notification: %v", err)
+		}
+	}
+
+	log.Printf("Data export %s completed for user %s (%d bytes)", exportID, userID, size)
+	return nil
+}
+
+// buildAndUpload writes the archive to a temporary file and uploads it,
+// returning the storage key and archive size
+func (es *DataExportService) buildAndUpload(ctx context.Context, userID, exportID string) (string, int64, error) {
+	if es.s3 == nil {
+		return "", 0, fmt.Errorf("storage not configured")
+	}
+
+	file, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Printf("Error closing temporary export file: %v", err)
+		}
+		if err := os.Remove(file.Name()); err != nil {
+			log.Printf("Error removing temporary export file: %v", err)
+		}
+	}()
+
+	if err := es.writeArchive(ctx, userID, file); err != nil {
+		return "", 0, err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to size archive: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	key := fmt.Sprintf("exports/%s/%s.zip", userID, exportID)
+	if _, err := es.s3.UploadObject(ctx, key, file, "application/zip"); err != nil {
+		return "", 0, err
+	}
+
+	return key, size, nil
+}
+
+// exportSections lists the JSON files in an archive and the query producing each.
+// Every query takes the user ID as its only argument.
+var exportSections = []struct {
+	file  string
+	query string
+}{
+	{"profile.json", `
+		SELECT id, email, first_name, last_name, age, bio, gender, latitude, longitude, city, state, country,
+		       is_active, role, last_seen, created_at, updated_at
+		FROM users WHERE id = $1`},
+	{"preferences.json", `SELECT * FROM user_preferences WHERE user_id = $1`},
+	{"notification_preferences.json", `SELECT * FROM notification_preferences WHERE user_id = $1`},
+	{"photos.json", `
+		SELECT id, s3_key, url, thumbnail_url, position, content_type, size, is_active, created_at, updated_at
+		FROM images WHERE user_id = $1 ORDER BY position`},
+	{"swipes.json", `
+		SELECT target_id, action, created_at FROM swipes WHERE user_id = $1 ORDER BY created_at`},
+	{"matches.json", `
+		SELECT id, CASE WHEN user1_id = $1 THEN user2_id ELSE user1_id END AS matched_user_id, is_active, created_at
+		FROM matches WHERE user1_id = $1 OR user2_id = $1 ORDER BY created_at`},
+	{"boosts.json", `
+		SELECT id, started_at, ends_at, impressions, created_at FROM boosts WHERE user_id = $1 ORDER BY started_at`},
+	{"messages.json", `
+		SELECT m.id, m.chat_id, m.sender_id = $1 AS sent_by_me, m.content, m.message_type, m.gif, m.is_read,
+		       m.edited_at, m.deleted_at, m.created_at
+		FROM messages m
+		JOIN chats c ON c.id = m.chat_id
+		JOIN matches mt ON mt.id = c.match_id
+		WHERE mt.user1_id = $1 OR mt.user2_id = $1
+		ORDER BY m.created_at`},
+	{"message_edits.json", `
+		SELECT e.message_id, e.content, e.edited_at
+		FROM message_edits e
+		JOIN messages m ON m.id = e.message_id
+		WHERE m.sender_id = $1
+		ORDER BY e.edited_at`},
+	{"message_reactions.json", `
+		SELECT message_id, emoji, created_at FROM message_reactions WHERE user_id = $1 ORDER BY created_at`},
+	{"chat_attachments.json", `
+		SELECT id, chat_id, message_id, s3_key, content_type, size, width, height, created_at
+		FROM chat_attachments WHERE uploader_id = $1 ORDER BY created_at`},
+	{"notifications.json", `
+		SELECT id, type, title, message, data, is_read, created_at
+		FROM notifications WHERE user_id = $1 ORDER BY created_at`},
+	{"analytics_events.json", `
+		SELECT id, event_type, event_data, session_id, ip_address, user_agent, created_at
+		FROM analytics_events WHERE user_id = $1 ORDER BY created_at`},
+}
+
+// writeArchive writes the user's data as a ZIP of JSON files followed by the
+// original photos under photos/ and the images they sent in chats under
+// attachments/
+func (es *DataExportService) writeArchive(ctx context.Context, userID string, w io.Writer) error {
+	archive := zip.NewWriter(w)
+
+	for _, section := range exportSections {
+		records, err := queryRecords(ctx, section.query, userID)
+		if err != nil {
+			return fmt.Errorf("failed to export %s: %w", section.file, err)
+		}
+		if err := writeJSONFile(archive, section.file, records); err != nil {
+			return err
+		}
+	}
+
+	if err := es.writeObjects(ctx, archive, "photos/",
+		`SELECT s3_key FROM images WHERE user_id = $1 ORDER BY position`, userID); err != nil {
+		return err
+	}
+	if err := es.writeObjects(ctx, archive, "attachments/",
+		`SELECT s3_key FROM chat_attachments WHERE uploader_id = $1 ORDER BY created_at`, userID); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+// writeObjects copies the stored objects whose keys the query returns into the
+// archive under dir
+func (es *DataExportService) writeObjects(ctx context.Context, archive *zip.Writer, dir, query, userID string) error {
+	rows, err := database.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", dir, err)
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			log.Printf("Error scanning object key: %v", err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+
+	for _, key := range keys {
+		body, _, err := es.s3.DownloadImage(ctx, key)
+		if err != nil {
+			return err
+		}
+		entry, err := archive.Create(dir + path.Base(key))
+		if err == nil {
+			_, err = io.Copy(entry, body)
+		}
+		if closeErr := body.Close(); closeErr != nil {
+			log.Printf("Error closing object body: %v", closeErr)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to add %s to archive: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// queryRecords runs a query and returns each row as a column name to value map
+func queryRecords(ctx context.Context, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	rows, err := database.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []map[string]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		record := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			record[column] = exportValue(values[i])
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// exportValue converts a raw driver value for JSON encoding. Text types arrive
+// as bytes; JSONB columns are embedded as JSON rather than as a string.
+func exportValue(value interface{}) interface{} {
+	b, ok := value.([]byte)
+	if !ok {
+		return value
+	}
+	if len(b) > 0 && (b[0] == '{' || b[0] == '[') && json.Valid(b) {
+		return json.RawMessage(b)
+	}
+	return string(b)
+}
+
+// writeJSONFile adds an indented JSON file to the archive
+func writeJSONFile(archive *zip.Writer, name string, data interface{}) error {
+	entry, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// scanExport scans a data_exports row
+func (es *DataExportService) scanExport(row *sql.Row) (*models.DataExport, error) {
+	var export models.DataExport
+	var s3Key, exportError sql.NullString
+	var size sql.NullInt64
+	err := row.Scan(&export.ID, &export.UserID, &export.Status, &s3Key, &size, &exportError,
+		&export.CreatedAt, &export.CompletedAt, &export.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	export.S3Key = s3Key.String
+	export.Size = size.Int64
+	export.Error = exportError.String
+	return &export, nil
+}
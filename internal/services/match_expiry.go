@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+	"matching-api/pkg/cache"
+)
+
+// DefaultMatchExtension is how much time extending a match adds unless
+// MATCH_EXTENSION_HOURS is set
+const DefaultMatchExtension = 24 * time.Hour
+
+// matchReminderLead is how long before expiry users are reminded, matching the
+// "expires in 24 hours" notification template
+const matchReminderLead = 24 * time.Hour
+
+// noMessagesYet is true for a match whose chat has no messages
+const noMessagesYet = `NOT EXISTS (
+	SELECT 1 FROM chats c JOIN messages msg ON msg.chat_id = c.id WHERE c.match_id = m.id
+)`
+
+// MatchExpiryService expires matches nobody has written in within a window.
+// Expiry is disabled unless MATCH_EXPIRY_HOURS is set.
+type MatchExpiryService struct {
+	cache         cache.Cache
+	notifications *NotificationService
+	window        time.Duration
+	extension     time.Duration
+}
+
+// NewMatchExpiryService creates a new match expiry service
+func NewMatchExpiryService(cacheStore cache.Cache, notifications *NotificationService) *MatchExpiryService {
+	var window time.Duration
+	if hours, err := strconv.Atoi(os.Getenv("MATCH_EXPIRY_HOURS")); err == nil && hours > 0 {
+		window = time.Duration(hours) * time.Hour
+	}
+
+	extension := DefaultMatchExtension
+	if hours, err := strconv.Atoi(os.Getenv("MATCH_EXTENSION_HOURS")); err == nil && hours > 0 {
+		extension = time.Duration(hours) * time.Hour
+	}
+
+	return &MatchExpiryService{
+		cache:         cacheStore,
+		notifications: notifications,
+		window:        window,
+		extension:     extension,
+	}
+}
+
+// Enabled reports whether matches expire at all
+func (es *MatchExpiryService) Enabled() bool {
+	return es.window > 0
+}
+
+// ExpiresAt returns when a match created at the given time expires, or nil if
+// expiry is disabled
+func (es *MatchExpiryService) ExpiresAt(createdAt time.Time) *time.Time {
+	if !es.Enabled() {
+		return nil
+	}
+	expiresAt := createdAt.Add(es.window)
+	return &expiresAt
+}
+
+// Extend pushes back the expiry of one of the user's matches. Each match can be
+// extended once. It returns nil if the match does not exist or is not the
+// user's, and false if it has no expiry or was already extended.
+func (es *MatchExpiryService) Extend(userID, matchID string) (*models.Match, bool, error) {
+	if database.DB == nil {
+		return nil, false, fmt.Errorf("database not available")
+	}
+
+	var match models.Match
+	err := database.DB.QueryRow(`
+		UPDATE matches
+		SET expires_at = GREATEST(expires_at, NOW()) + $3 * INTERVAL '1 second',
+		    extended_at = NOW(), expiry_reminder_sent_at = NULL
+		WHERE id = $1 AND (user1_id = $2 OR user2_id = $2) AND is_active = true
+		  AND expires_at IS NOT NULL AND extended_at IS NULL
+		RETURNING id, user1_id, user2_id, is_active, created_at, expires_at, extended_at
+	`, matchID, userID, int64(es.extension.Seconds())).Scan(&match.ID, &match.User1ID, &match.User2ID,
+		&match.IsActive, &match.CreatedAt, &match.ExpiresAt, &match.ExtendedAt)
+	if err == nil {
+		es.clearMatchCaches(match.User1ID, match.User2ID)
+		return &match, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, fmt.Errorf("failed to extend match: %w", err)
+	}
+
+	// Tell a missing match apart from one that cannot be extended
+	err = database.DB.QueryRow(`
+		SELECT id, user1_id, user2_id, is_active, created_at, expires_at, extended_at
+		FROM matches WHERE id = $1 AND (user1_id = $2 OR user2_id = $2) AND is_active = true
+	`, matchID, userID).Scan(&match.ID, &match.User1ID, &match.User2ID,
+		&match.IsActive, &match.CreatedAt, &match.ExpiresAt, &match.ExtendedAt)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load match: %w", err)
+	}
+	return &match, false, nil
+}
+
+// Start sends reminders and expires matches every interval until the context
+// is cancelled. It does nothing while expiry is disabled.
+func (es *MatchExpiryService) Start(ctx context.Context, interval time.Duration) {
+	if !es.Enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if database.DB == nil {
+				continue
+			}
+			if sent, err := es.SendReminders(ctx); err != nil {
+				log.Printf("Match expiry reminders failed: %v", err)
+			} else if sent > 0 {
+				log.Printf("Sent expiry reminders for %d matches", sent)
+			}
+			if expired, err := es.ExpireMatches(ctx); err != nil {
+				log.Printf("Match expiry failed: %v", err)
+			} else if expired > 0 {
+				log.Printf("Expired %d matches", expired)
+			}
+		}
+	}
+}
+
+// SendReminders notifies both users of every match without messages that
+// expires within the next day. Each expiry is reminded about once.
+func (es *MatchExpiryService) SendReminders(ctx context.Context) (int, error) {
+	// Claim the reminders before sending so concurrent runs don't send twice
+	rows, err := database.DB.QueryContext(ctx, `
+		UPDATE matches m SET expiry_reminder_sent_at = NOW()
+		FROM users u1, users u2
+		WHERE u1.id = m.user1_id AND u2.id = m.user2_id
+		  AND m.is_active = true AND m.expiry_reminder_sent_at IS NULL
+		  AND m.expires_at > NOW() AND m.expires_at <= NOW() + $1 * INTERVAL '1 second'
+		  AND `+noMessagesYet+`
+		RETURNING m.id, m.user1_id, u1.first_name, m.user2_id, u2.first_name
+	`, int64(matchReminderLead.Seconds()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim match reminders: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			log.Printf("Error closing rows: %v", err)
+		}
+	}()
+
+	sent := 0
+	for rows.Next() {
+		var matchID, user1ID, user1Name, user2ID, user2Name string
+		if err := rows.Scan(&matchID, &user1ID, &user1Name, &user2ID, &user2Name); err != nil {
+			log.Printf("Error scanning expiring match: %v", err)
+			continue
+		}
+
+		if err := es.notifications.SendMatchExpiringNotification(user1ID, matchID, user2Name); err != nil {
+			log.Printf("Failed to send match expiring notification: %v", err)
+		}
+		if err := es.notifications.SendMatchExpiringNotification(user2ID, matchID, user1Name); err != nil {
+			log.Printf("Failed to send match expiring notification: %v", err)
+		}
+		sent++
+	}
+
+	return sent, rows.Err()
+}
+
+// ExpireMatches deactivates every match without messages whose expiry has
+// passed, together with its chat
+func (es *MatchExpiryService) ExpireMatches(ctx context.Context) (int, error) {
+	tx, err := database.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Printf("Error rolling back match expiry: %v", err)
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE matches m SET is_active = false, expired_at = NOW()
+		WHERE m.is_active = true AND m.expires_at <= NOW() AND `+noMessagesYet+`
+		RETURNING m.id, m.user1_id, m.user2_id
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire matches: %w", err)
+	}
+
+	var matchIDs, userIDs []string
+	for rows.Next() {
+		var matchID, user1ID, user2ID string
+		if err := rows.Scan(&matchID, &user1ID, &user2ID); err != nil {
+			if err := rows.Close(); err != nil {
+				log.Printf("Error closing rows: %v", err)
+			}
+			return 0, err
+		}
+		matchIDs = append(matchIDs, matchID)
+		userIDs = append(userIDs, user1ID, user2ID)
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing rows: %v", err)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(matchIDs) == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE chats SET is_active = false, updated_at = NOW() WHERE match_id = ANY($1)
+	`, pq.Array(matchIDs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to deactivate expired chats: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	es.clearMatchCaches(userIDs...)
+	return len(matchIDs), nil
+}
+
+// clearMatchCaches drops the cached match lists of the given users
+func (es *MatchExpiryService) clearMatchCaches(userIDs ...string) {
+	for _, userID := range userIDs {
+		if err := es.cache.InvalidateTags(cache.MatchesTag(userID)); err != nil {
+			log.Printf("Warning: Failed to invalidate match cache: %v", err)
+		}
+	}
+}
@@ -305,6 +305,64 @@ func (ns *NotificationService) SendSuperLikeNotification(recipientID, senderID,
 	return nil
 }
 
+// SendDataExportNotification tells a user their data export is ready to download
+func (ns *NotificationService) SendDataExportNotification(userID, exportID string) error {
+	data := map[string]interface{}{
+		"export_id": exportID,
+	}
+
+	notification, err := ns.CreateNotification(userID, models.NotificationDataExportReady, data)
+	if err != nil {
+		return err
+	}
+
+	deviceTokens := ns.getUserDeviceTokens(userID)
+	payload := models.PushNotificationPayload{
+		Title: notification.Title,
+		Body:  notification.Message,
+		Data:  notification.Data,
+		Sound: "default",
+	}
+
+	go func() {
+		if err := ns.SendPushNotification(deviceTokens, payload); err != nil {
+			log.Printf("Error sending data export push notification: %v", err)
+		}
+	}()
+
+	return nil
+}
+
+// SendMatchExpiringNotification reminds a user that a match without messages is about to expire
+func (ns *NotificationService) SendMatchExpiringNotification(userID, matchID, matchName string) error {
+	data := map[string]interface{}{
+		"match_id":   matchID,
+		"match_name": matchName,
+	}
+
+	notification, err := ns.CreateNotification(userID, models.NotificationMatchExpiring, data)
+	if err != nil {
+		return err
+	}
+
+	deviceTokens := ns.getUserDeviceTokens(userID)
+	payload := models.PushNotificationPayload{
+		Title: notification.Title,
+		Body:  notification.Message,
+		Data:  notification.Data,
+		Badge: 1,
+		Sound: "default",
+	}
+
+	go func() {
+		if err := ns.SendPushNotification(deviceTokens, payload); err != nil {
+			log.Printf("Error sending match expiring push notification: %v", err)
+		}
+	}()
+
+	return nil
+}
+
 // MarkNotificationAsRead marks a notification as read
 func (ns *NotificationService) MarkNotificationAsRead(notificationID string) error {
 	// In a real app, update database
@@ -0,0 +1,391 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"matching-api/internal/database"
+	"matching-api/internal/models"
+	"matching-api/pkg/services"
+)
+
+const (
+	quotaFieldLikes      = "likes"
+	quotaFieldSuperLikes = "super_likes"
+	quotaFieldRewinds    = "rewinds"
+)
+
+// QuotaStore counts swipes per user and local calendar day
+type QuotaStore interface {
+	// Increment adds one to a counter and returns the new value
+	Increment(userID, day, field string, ttl time.Duration) (int64, error)
+	// Decrement takes one from a counter, undoing an Increment
+	Decrement(userID, day, field string) error
+	// Counts returns every counter for the day
+	Counts(userID, day string) (map[string]int64, error)
+}
+
+// NewQuotaStore returns a Redis-backed store when Redis is available and falls
+// back to an in-memory store otherwise
+func NewQuotaStore(redisService *services.RedisService) QuotaStore {
+	if redisService != nil {
+		return &RedisQuotaStore{redis: redisService}
+	}
+	return NewMemoryQuotaStore()
+}
+
+// RedisQuotaStore keeps swipe counters in Redis so they are shared between API instances
+type RedisQuotaStore struct {
+	redis *services.RedisService
+}
+
+// Increment adds one to a counter and returns the new value
+func (s *RedisQuotaStore) Increment(userID, day, field string, ttl time.Duration) (int64, error) {
+	return s.redis.IncrementSwipeCount(userID, day, field, ttl)
+}
+
+// Decrement takes one from a counter
+func (s *RedisQuotaStore) Decrement(userID, day, field string) error {
+	return s.redis.DecrementSwipeCount(userID, day, field)
+}
+
+// Counts returns every counter for the day
+func (s *RedisQuotaStore) Counts(userID, day string) (map[string]int64, error) {
+	return s.redis.GetSwipeCounts(userID, day)
+}
+
+// MemoryQuotaStore keeps swipe counters in process memory. Counters are not
+// shared between instances, so it is only suitable for development.
+type MemoryQuotaStore struct {
+	mu       sync.Mutex
+	counters map[string]map[string]int64
+	expiry   map[string]time.Time
+}
+
+// NewMemoryQuotaStore creates an in-memory quota store
+func NewMemoryQuotaStore() *MemoryQuotaStore {
+	return &MemoryQuotaStore{
+		counters: make(map[string]map[string]int64),
+		expiry:   make(map[string]time.Time),
+	}
+}
+
+// Increment adds one to a counter and returns the new value
+func (s *MemoryQuotaStore) Increment(userID, day, field string, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.cleanup()
+	key := userID + ":" + day
+	if s.counters[key] == nil {
+		s.counters[key] = make(map[string]int64)
+	}
+	s.counters[key][field]++
+	s.expiry[key] = time.Now().Add(ttl)
+
+	return s.counters[key][field], nil
+}
+
+// Decrement takes one from a counter
+func (s *MemoryQuotaStore) Decrement(userID, day, field string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if counts := s.counters[userID+":"+day]; counts != nil && counts[field] > 0 {
+		counts[field]--
+	}
+	return nil
+}
+
+// Counts returns every counter for the day
+func (s *MemoryQuotaStore) Counts(userID, day string) (map[string]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make(map[string]int64)
+	for field, count := range s.counters[userID+":"+day] {
+		counts[field] = count
+	}
+	return counts, nil
+}
+
+// cleanup drops expired counters; callers must hold the lock
+func (s *MemoryQuotaStore) cleanup() {
+	now := time.Now()
+	for key, expiresAt := range s.expiry {
+		if now.After(expiresAt) {
+			delete(s.counters, key)
+			delete(s.expiry, key)
+		}
+	}
+}
+
+// EntitlementProvider resolves the daily allowances a user is entitled to.
+// It is the hook for billing: premium plans get different limits.
+type EntitlementProvider interface {
+	Entitlements(userID string) (*models.Entitlements, error)
+}
+
+// TierEntitlementProvider maps the user's subscription_tier column to limits
+type TierEntitlementProvider struct {
+	tiers map[models.SubscriptionTier]models.Entitlements
+}
+
+// NewTierEntitlementProvider creates a provider with the default tier limits.
+// Free tier limits can be overridden with DAILY_LIKE_LIMIT, DAILY_SUPER_LIKE_LIMIT
+// and DAILY_REWIND_LIMIT.
+func NewTierEntitlementProvider() *TierEntitlementProvider {
+	free := models.Entitlements{Tier: models.TierFree, DailyLikes: 100, DailySuperLikes: 1, DailyRewinds: 1}
+	if limit, err := strconv.Atoi(os.Getenv("DAILY_LIKE_LIMIT")); err == nil {
+		free.DailyLikes = limit
+	}
+	if limit, err := strconv.Atoi(os.Getenv("DAILY_SUPER_LIKE_LIMIT")); err == nil {
+		free.DailySuperLikes = limit
+	}
+	if limit, err := strconv.Atoi(os.Getenv("DAILY_REWIND_LIMIT")); err == nil {
+		free.DailyRewinds = limit
+	}
+
+	return &TierEntitlementProvider{
+		tiers: map[models.SubscriptionTier]models.Entitlements{
+			models.TierFree:    free,
+			models.TierPlus:    {Tier: models.TierPlus, DailyLikes: models.UnlimitedQuota, DailySuperLikes: 3, DailyRewinds: models.UnlimitedQuota, SeeWhoLikesYou: true},
+			models.TierPremium: {Tier: models.TierPremium, DailyLikes: models.UnlimitedQuota, DailySuperLikes: 5, DailyRewinds: models.UnlimitedQuota, SeeWhoLikesYou: true},
+		},
+	}
+}
+
+// Entitlements returns the limits of the user's tier, or the free tier if the
+// user or database is not available
+func (p *TierEntitlementProvider) Entitlements(userID string) (*models.Entitlements, error) {
+	tier := models.TierFree
+	if database.DB != nil {
+		err := database.DB.QueryRow(`SELECT subscription_tier FROM users WHERE id = $1`, userID).Scan(&tier)
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to load subscription tier: %w", err)
+		}
+	}
+
+	entitlements, ok := p.tiers[tier]
+	if !ok {
+		entitlements = p.tiers[models.TierFree]
+	}
+	return &entitlements, nil
+}
+
+// QuotaService enforces daily like, super like and rewind allowances. Days follow the
+// user's own timezone, so allowances reset at local midnight.
+type QuotaService struct {
+	store        QuotaStore
+	entitlements EntitlementProvider
+}
+
+// NewQuotaService creates a new quota service
+func NewQuotaService(redisService *services.RedisService, entitlements EntitlementProvider) *QuotaService {
+	return &QuotaService{
+		store:        NewQuotaStore(redisService),
+		entitlements: entitlements,
+	}
+}
+
+// Entitlements returns what the user's subscription tier grants
+func (qs *QuotaService) Entitlements(userID string) (*models.Entitlements, error) {
+	return qs.entitlements.Entitlements(userID)
+}
+
+// Tier returns the user's subscription tier
+func (qs *QuotaService) Tier(userID string) (models.SubscriptionTier, error) {
+	entitlements, err := qs.entitlements.Entitlements(userID)
+	if err != nil {
+		return "", err
+	}
+	return entitlements.Tier, nil
+}
+
+// GetQuota returns the user's allowances and usage for the current local day
+func (qs *QuotaService) GetQuota(userID string) (*models.SwipeQuota, error) {
+	entitlements, err := qs.entitlements.Entitlements(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := qs.userLocation(userID)
+	day, resetsAt := quotaDay(time.Now(), loc)
+	counts, err := qs.store.Counts(userID, day)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildQuota(entitlements, counts, loc, resetsAt), nil
+}
+
+// Consume uses one like or super like from today's allowance. It returns false
+// without counting anything if the allowance is used up. Passes are free.
+func (qs *QuotaService) Consume(userID string, action models.SwipeAction) (*models.SwipeQuota, bool, error) {
+	return qs.consume(userID, func(entitlements *models.Entitlements) (string, int) {
+		return quotaField(action, entitlements)
+	})
+}
+
+// ConsumeRewind uses one rewind from today's allowance, returning false if none are left
+func (qs *QuotaService) ConsumeRewind(userID string) (*models.SwipeQuota, bool, error) {
+	return qs.consume(userID, rewindField)
+}
+
+// Refund returns a like or super like to today's allowance, e.g. when the
+// swipe could not be saved
+func (qs *QuotaService) Refund(userID string, action models.SwipeAction) error {
+	return qs.RefundAt(userID, action, time.Now())
+}
+
+// RefundAt returns a like or super like used at the given time, e.g. when the
+// swipe was undone. Nothing is refunded if it was used on an earlier local
+// day, since today's allowance never paid for it.
+func (qs *QuotaService) RefundAt(userID string, action models.SwipeAction, usedAt time.Time) error {
+	return qs.refund(userID, usedAt, func(entitlements *models.Entitlements) (string, int) {
+		return quotaField(action, entitlements)
+	})
+}
+
+// RefundRewind returns a rewind to today's allowance
+func (qs *QuotaService) RefundRewind(userID string) error {
+	return qs.refund(userID, time.Now(), rewindField)
+}
+
+// consume counts one use of the counter selected by fieldFor against its limit
+func (qs *QuotaService) consume(userID string, fieldFor func(*models.Entitlements) (string, int)) (*models.SwipeQuota, bool, error) {
+	entitlements, err := qs.entitlements.Entitlements(userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	loc := qs.userLocation(userID)
+	day, resetsAt := quotaDay(time.Now(), loc)
+
+	field, limit := fieldFor(entitlements)
+	if field != "" && limit != models.UnlimitedQuota {
+		// Keep the counter a little past midnight so late requests still see it
+		ttl := time.Until(resetsAt) + time.Hour
+		count, err := qs.store.Increment(userID, day, field, ttl)
+		if err != nil {
+			return nil, false, err
+		}
+		if count > int64(limit) {
+			if err := qs.store.Decrement(userID, day, field); err != nil {
+				return nil, false, err
+			}
+			quota, err := qs.quotaFor(userID, entitlements, loc, day, resetsAt)
+			return quota, false, err
+		}
+	}
+
+	quota, err := qs.quotaFor(userID, entitlements, loc, day, resetsAt)
+	return quota, true, err
+}
+
+// refund undoes one use at usedAt of the counter selected by fieldFor, if it
+// was counted against the current day
+func (qs *QuotaService) refund(userID string, usedAt time.Time, fieldFor func(*models.Entitlements) (string, int)) error {
+	entitlements, err := qs.entitlements.Entitlements(userID)
+	if err != nil {
+		return err
+	}
+
+	field, limit := fieldFor(entitlements)
+	if field == "" || limit == models.UnlimitedQuota {
+		return nil
+	}
+
+	loc := qs.userLocation(userID)
+	day, _ := quotaDay(time.Now(), loc)
+	if usedDay, _ := quotaDay(usedAt, loc); usedDay != day {
+		return nil
+	}
+	return qs.store.Decrement(userID, day, field)
+}
+
+// quotaFor loads the current counters and builds the quota report
+func (qs *QuotaService) quotaFor(userID string, entitlements *models.Entitlements, loc *time.Location, day string, resetsAt time.Time) (*models.SwipeQuota, error) {
+	counts, err := qs.store.Counts(userID, day)
+	if err != nil {
+		return nil, err
+	}
+	return buildQuota(entitlements, counts, loc, resetsAt), nil
+}
+
+// userLocation returns the user's timezone, defaulting to UTC
+func (qs *QuotaService) userLocation(userID string) *time.Location {
+	if database.DB == nil {
+		return time.UTC
+	}
+
+	var timezone sql.NullString
+	if err := database.DB.QueryRow(`SELECT timezone FROM users WHERE id = $1`, userID).Scan(&timezone); err != nil {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(timezone.String)
+	if err != nil || timezone.String == "" {
+		return time.UTC
+	}
+	return loc
+}
+
+// quotaField returns the counter and limit an action draws from. Passes are
+// not counted and return an empty field.
+func quotaField(action models.SwipeAction, entitlements *models.Entitlements) (string, int) {
+	switch action {
+	case models.SwipeRight:
+		return quotaFieldLikes, entitlements.DailyLikes
+	case models.SuperLike:
+		return quotaFieldSuperLikes, entitlements.DailySuperLikes
+	default:
+		return "", models.UnlimitedQuota
+	}
+}
+
+// rewindField returns the rewind counter and limit
+func rewindField(entitlements *models.Entitlements) (string, int) {
+	return quotaFieldRewinds, entitlements.DailyRewinds
+}
+
+// quotaDay returns the local calendar day used as the counter key and the
+// next local midnight, when the counters reset
+func quotaDay(now time.Time, loc *time.Location) (string, time.Time) {
+	local := now.In(loc)
+	year, month, day := local.Date()
+	return local.Format("2006-01-02"), time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+}
+
+// buildQuota combines limits and usage into a quota report
+func buildQuota(entitlements *models.Entitlements, counts map[string]int64, loc *time.Location, resetsAt time.Time) *models.SwipeQuota {
+	quota := &models.SwipeQuota{
+		Tier:            entitlements.Tier,
+		LikesLimit:      entitlements.DailyLikes,
+		LikesUsed:       int(counts[quotaFieldLikes]),
+		SuperLikesLimit: entitlements.DailySuperLikes,
+		SuperLikesUsed:  int(counts[quotaFieldSuperLikes]),
+		RewindsLimit:    entitlements.DailyRewinds,
+		RewindsUsed:     int(counts[quotaFieldRewinds]),
+		Timezone:        loc.String(),
+		ResetsAt:        resetsAt,
+	}
+	quota.LikesRemaining = remaining(quota.LikesLimit, quota.LikesUsed)
+	quota.SuperLikesRemaining = remaining(quota.SuperLikesLimit, quota.SuperLikesUsed)
+	quota.RewindsRemaining = remaining(quota.RewindsLimit, quota.RewindsUsed)
+	return quota
+}
+
+// remaining returns what is left of a limit, or -1 when it is unlimited
+func remaining(limit, used int) int {
+	if limit == models.UnlimitedQuota {
+		return models.UnlimitedQuota
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
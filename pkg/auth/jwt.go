@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"matching-api/internal/models"
 )
 
@@ -27,14 +28,22 @@ func NewJWTService(secretKey string) *JWTService {
 	}
 }
 
-// GenerateAccessToken generates a new access token
-func (j *JWTService) GenerateAccessToken(userID, email string) (string, error) {
+// GenerateAccessToken generates a new access token bound to a device session
+func (j *JWTService) GenerateAccessToken(userID, email string, role models.Role, sessionID string) (string, error) {
+	if role == "" {
+		role = models.RoleUser
+	}
+
 	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
+		"role":    string(role),
+		"sid":     sessionID,
+		"jti":     uuid.New().String(),
 		"exp":     now.Add(AccessTokenExpiry).Unix(),
 		"iat":     now.Unix(),
+		"iat_ms":  now.UnixMilli(), // Compared against revocation watermarks
 		"type":    "access",
 	}
 
@@ -98,11 +107,27 @@ func (j *JWTService) ValidateAccessToken(tokenString string) (*models.JWTClaims,
 		return nil, fmt.Errorf("invalid iat claim")
 	}
 
+	// Session ID and token ID are optional so tokens issued before they were
+	// introduced remain valid until they expire
+	sessionID, _ := claims["sid"].(string)
+	tokenID, _ := claims["jti"].(string)
+	iatMs, _ := claims["iat_ms"].(float64)
+
+	// Tokens issued before roles existed belong to regular users
+	role := models.RoleUser
+	if roleClaim, ok := claims["role"].(string); ok && models.Role(roleClaim).IsValid() {
+		role = models.Role(roleClaim)
+	}
+
 	return &models.JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Exp:    int64(exp),
-		Iat:    int64(iat),
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		SessionID: sessionID,
+		TokenID:   tokenID,
+		Exp:       int64(exp),
+		Iat:       int64(iat),
+		IatMs:     int64(iatMs),
 	}, nil
 }
 
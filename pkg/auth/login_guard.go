@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"matching-api/pkg/services"
+)
+
+const (
+	LoginFailureWindow    = 15 * time.Minute // how long failed attempts are remembered
+	LoginFreeAttempts     = 3                // failures allowed before delays apply
+	LoginBaseDelay        = 1 * time.Second  // first delay, doubled per further failure
+	LoginMaxDelay         = 60 * time.Second
+	LoginLockoutThreshold = 10 // failures that trigger a lockout
+	LoginLockoutDuration  = 15 * time.Minute
+)
+
+// LoginAttemptStore persists failed login counters and lockouts per account
+type LoginAttemptStore interface {
+	// Begin checks the lockout and the delay owed for earlier failures and, if
+	// the attempt may proceed, counts it as a failure until Reset is called.
+	// Checking and counting happen in one atomic step.
+	Begin(key string, now time.Time) (*LoginAttempt, error)
+	// Reset clears the failure count and any lockout
+	Reset(key string) error
+}
+
+// LoginAttempt is the outcome of starting a login attempt
+type LoginAttempt struct {
+	Failures    int           // Failures counted, including this attempt if it may proceed
+	RetryAfter  time.Duration // How long to wait if the attempt may not proceed
+	Locked      bool          // Logins are locked out
+	LockStarted bool          // This attempt reached the threshold and started a lockout
+}
+
+// LoginGuard throttles password attempts per account, independent of the
+// client IP, with progressive delays followed by a temporary lockout.
+// Counters are kept for unknown emails too so responses do not reveal
+// whether an account exists.
+type LoginGuard struct {
+	store LoginAttemptStore
+}
+
+// NewLoginGuard returns a guard backed by Redis when available, falling back
+// to an in-memory store otherwise
+func NewLoginGuard(redisService *services.RedisService) *LoginGuard {
+	if redisService != nil {
+		return &LoginGuard{store: &redisLoginAttemptStore{redis: redisService}}
+	}
+	return &LoginGuard{store: newMemoryLoginAttemptStore()}
+}
+
+// Begin starts a login attempt for the email. Unless the account is locked or
+// an earlier failure's delay has not passed, the attempt is counted as a
+// failure up front, so parallel guesses can't all get through before any of
+// them is recorded; RecordSuccess clears the count again. The attempt that
+// reaches the lockout threshold may still proceed and starts the lockout.
+func (g *LoginGuard) Begin(email string) (*LoginAttempt, error) {
+	return g.store.Begin(LoginKey(email), time.Now())
+}
+
+// RecordSuccess clears the failure count and any lockout after a successful login
+func (g *LoginGuard) RecordSuccess(email string) error {
+	return g.store.Reset(LoginKey(email))
+}
+
+// loginDelay returns the required wait after the given number of failures
+func loginDelay(failures int) time.Duration {
+	if failures < LoginFreeAttempts {
+		return 0
+	}
+
+	delay := LoginBaseDelay
+	for i := LoginFreeAttempts; i < failures && delay < LoginMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > LoginMaxDelay {
+		delay = LoginMaxDelay
+	}
+	return delay
+}
+
+// LoginKey normalizes and hashes an email so raw addresses are not stored as keys
+func LoginKey(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
+
+// redisLoginAttemptStore keeps counters in Redis so limits hold across instances
+type redisLoginAttemptStore struct {
+	redis *services.RedisService
+}
+
+// loginAttemptPolicy passes the guard's limits to Redis
+var loginAttemptPolicy = services.LoginAttemptPolicy{
+	FreeAttempts:     LoginFreeAttempts,
+	BaseDelay:        LoginBaseDelay,
+	MaxDelay:         LoginMaxDelay,
+	LockoutThreshold: LoginLockoutThreshold,
+	LockoutDuration:  LoginLockoutDuration,
+	Window:           LoginFailureWindow,
+}
+
+func (s *redisLoginAttemptStore) Begin(key string, now time.Time) (*LoginAttempt, error) {
+	outcome, wait, failures, err := s.redis.TakeLoginAttempt(key, loginAttemptPolicy, now)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginAttempt{
+		Failures:    int(failures),
+		RetryAfter:  wait,
+		Locked:      outcome == services.LoginAttemptLocked,
+		LockStarted: outcome == services.LoginAttemptLockStarted,
+	}, nil
+}
+
+func (s *redisLoginAttemptStore) Reset(key string) error {
+	return s.redis.ResetLoginFailures(key)
+}
+
+// memoryLoginAttemptStore is used when Redis is not configured
+type memoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	failures map[string]*loginFailures
+	locks    map[string]time.Time
+}
+
+type loginFailures struct {
+	count     int
+	last      time.Time
+	expiresAt time.Time
+}
+
+func newMemoryLoginAttemptStore() *memoryLoginAttemptStore {
+	return &memoryLoginAttemptStore{
+		failures: make(map[string]*loginFailures),
+		locks:    make(map[string]time.Time),
+	}
+}
+
+func (s *memoryLoginAttemptStore) Begin(key string, now time.Time) (*LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked(now)
+	if until, exists := s.locks[key]; exists {
+		return &LoginAttempt{RetryAfter: until.Sub(now), Locked: true}, nil
+	}
+
+	entry, exists := s.failures[key]
+	if !exists {
+		entry = &loginFailures{}
+		s.failures[key] = entry
+	}
+	if wait := entry.last.Add(loginDelay(entry.count)).Sub(now); wait > 0 {
+		return &LoginAttempt{Failures: entry.count, RetryAfter: wait}, nil
+	}
+
+	entry.count++
+	if entry.count >= LoginLockoutThreshold {
+		// Start from a clean slate once the lockout expires
+		delete(s.failures, key)
+		s.locks[key] = now.Add(LoginLockoutDuration)
+		return &LoginAttempt{Failures: entry.count, LockStarted: true}, nil
+	}
+	entry.last = now
+	entry.expiresAt = now.Add(LoginFailureWindow)
+	return &LoginAttempt{Failures: entry.count}, nil
+}
+
+func (s *memoryLoginAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, key)
+	delete(s.locks, key)
+	return nil
+}
+
+// pruneLocked drops expired counters and lockouts
+func (s *memoryLoginAttemptStore) pruneLocked(now time.Time) {
+	for key, entry := range s.failures {
+		if now.After(entry.expiresAt) {
+			delete(s.failures, key)
+		}
+	}
+	for key, until := range s.locks {
+		if !now.Before(until) {
+			delete(s.locks, key)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	"matching-api/internal/models"
+	"matching-api/pkg/services"
+)
+
+// RevocationStore tracks access tokens that must be rejected before they expire.
+// Individual tokens (jti) and device sessions (sid) are denylisted by ID, and a
+// per-user watermark invalidates every token issued before a point in time.
+type RevocationStore interface {
+	// Revoke denylists a token or session ID until the given time
+	Revoke(id string, until time.Time) error
+	// IsRevoked reports whether a token or session ID is denylisted
+	IsRevoked(id string) (bool, error)
+	// RevokeUserTokens invalidates all tokens issued to a user up to the given time
+	RevokeUserTokens(userID string, before time.Time) error
+	// UserTokensRevokedBefore returns the user's watermark, or the zero time if unset
+	UserTokensRevokedBefore(userID string) (time.Time, error)
+}
+
+// NewRevocationStore returns a Redis-backed store when Redis is available and
+// falls back to an in-memory store otherwise
+func NewRevocationStore(redisService *services.RedisService) RevocationStore {
+	if redisService != nil {
+		return NewRedisRevocationStore(redisService)
+	}
+	return NewMemoryRevocationStore()
+}
+
+// IsClaimsRevoked checks the token ID, session ID and issue time of the claims
+// against the store
+func IsClaimsRevoked(store RevocationStore, claims *models.JWTClaims) (bool, error) {
+	for _, id := range []string{claims.TokenID, claims.SessionID} {
+		if id == "" {
+			continue
+		}
+		revoked, err := store.IsRevoked(id)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	before, err := store.UserTokensRevokedBefore(claims.UserID)
+	if err != nil || before.IsZero() {
+		return false, err
+	}
+
+	// Tokens issued in the same millisecond as the watermark are revoked too,
+	// since they may predate it. Older tokens only carry whole seconds.
+	if claims.IatMs == 0 {
+		return claims.Iat <= before.Unix(), nil
+	}
+	return claims.IatMs <= before.UnixMilli(), nil
+}
+
+// RedisRevocationStore keeps the denylist and watermarks in Redis so they are
+// shared between API instances
+type RedisRevocationStore struct {
+	redis *services.RedisService
+}
+
+// NewRedisRevocationStore creates a Redis-backed revocation store
+func NewRedisRevocationStore(redisService *services.RedisService) *RedisRevocationStore {
+	return &RedisRevocationStore{redis: redisService}
+}
+
+// Revoke denylists an ID until the given time
+func (s *RedisRevocationStore) Revoke(id string, until time.Time) error {
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return nil
+	}
+	return s.redis.RevokeToken(id, ttl)
+}
+
+// IsRevoked reports whether an ID is denylisted
+func (s *RedisRevocationStore) IsRevoked(id string) (bool, error) {
+	return s.redis.IsTokenRevoked(id)
+}
+
+// RevokeUserTokens sets the user's watermark. It only needs to outlive the
+// longest-lived access token issued before it.
+func (s *RedisRevocationStore) RevokeUserTokens(userID string, before time.Time) error {
+	return s.redis.SetTokensRevokedBefore(userID, before, AccessTokenExpiry)
+}
+
+// UserTokensRevokedBefore returns the user's watermark
+func (s *RedisRevocationStore) UserTokensRevokedBefore(userID string) (time.Time, error) {
+	return s.redis.GetTokensRevokedBefore(userID)
+}
+
+// MemoryRevocationStore is an in-process revocation store used when Redis is
+// not configured. Entries are not shared between instances.
+type MemoryRevocationStore struct {
+	mu        sync.RWMutex
+	revoked   map[string]time.Time
+	watermark map[string]time.Time
+}
+
+// NewMemoryRevocationStore creates an in-memory revocation store
+func NewMemoryRevocationStore() *MemoryRevocationStore {
+	return &MemoryRevocationStore{
+		revoked:   make(map[string]time.Time),
+		watermark: make(map[string]time.Time),
+	}
+}
+
+// Revoke denylists an ID until the given time
+func (s *MemoryRevocationStore) Revoke(id string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked()
+	if until.After(time.Now()) {
+		s.revoked[id] = until
+	}
+	return nil
+}
+
+// IsRevoked reports whether an ID is denylisted
+func (s *MemoryRevocationStore) IsRevoked(id string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	until, exists := s.revoked[id]
+	return exists && time.Now().Before(until), nil
+}
+
+// RevokeUserTokens sets the user's watermark
+func (s *MemoryRevocationStore) RevokeUserTokens(userID string, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked()
+	s.watermark[userID] = before
+	return nil
+}
+
+// UserTokensRevokedBefore returns the user's watermark
+func (s *MemoryRevocationStore) UserTokensRevokedBefore(userID string) (time.Time, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.watermark[userID], nil
+}
+
+// pruneLocked drops entries that can no longer match an unexpired token
+func (s *MemoryRevocationStore) pruneLocked() {
+	now := time.Now()
+	for id, until := range s.revoked {
+		if now.After(until) {
+			delete(s.revoked, id)
+		}
+	}
+	for userID, before := range s.watermark {
+		if now.After(before.Add(AccessTokenExpiry)) {
+			delete(s.watermark, userID)
+		}
+	}
+}
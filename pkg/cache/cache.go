@@ -0,0 +1,78 @@
+package cache
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"matching-api/pkg/services"
+)
+
+// Backends selectable with CACHE_BACKEND
+const (
+	BackendRedis   = "redis"   // Shared Redis cache
+	BackendMemory  = "memory"  // Per-instance in-memory LRU
+	BackendLayered = "layered" // In-memory LRU in front of Redis
+)
+
+// Defaults unless CACHE_MEMORY_MAX_ENTRIES and CACHE_LOCAL_TTL_SECONDS are set
+const (
+	DefaultMemoryMaxEntries = 10000
+	DefaultLocalTTL         = 30 * time.Second
+)
+
+// ErrMiss is returned by Get when the key is not cached
+var ErrMiss = services.ErrCacheMiss
+
+// Cache stores JSON-encoded values with a TTL. Entries can be registered under
+// tags and invalidated together.
+type Cache interface {
+	// Get decodes the cached value into dest, or returns ErrMiss
+	Get(key string, dest any) error
+	// Set stores a value for ttl and registers it under the given tags
+	Set(key string, value any, ttl time.Duration, tags ...string) error
+	// Delete removes the given keys
+	Delete(keys ...string) error
+	// InvalidateTags removes every entry registered under any of the tags
+	InvalidateTags(tags ...string) error
+	// Increment adds delta to a counter and returns the new value. A new
+	// counter expires after ttl; a zero ttl keeps it until deleted.
+	Increment(key string, delta int64, ttl time.Duration) (int64, error)
+	// Expire sets a new TTL on a key
+	Expire(key string, ttl time.Duration) error
+}
+
+// New creates the cache selected by CACHE_BACKEND. It defaults to Redis when
+// Redis is connected and falls back to memory when it is not.
+func New(redisService *services.RedisService) Cache {
+	backend := os.Getenv("CACHE_BACKEND")
+	if backend == "" {
+		backend = BackendRedis
+	}
+	if redisService == nil && backend != BackendMemory {
+		log.Printf("Cache backend %q needs Redis, using memory instead", backend)
+		backend = BackendMemory
+	}
+
+	maxEntries := DefaultMemoryMaxEntries
+	if n, err := strconv.Atoi(os.Getenv("CACHE_MEMORY_MAX_ENTRIES")); err == nil && n > 0 {
+		maxEntries = n
+	}
+
+	switch backend {
+	case BackendMemory:
+		return NewMemoryCache(maxEntries)
+	case BackendLayered:
+		localTTL := DefaultLocalTTL
+		if seconds, err := strconv.Atoi(os.Getenv("CACHE_LOCAL_TTL_SECONDS")); err == nil && seconds > 0 {
+			localTTL = time.Duration(seconds) * time.Second
+		}
+		return NewLayeredCache(NewMemoryCache(maxEntries), NewRedisCache(redisService), localTTL)
+	case BackendRedis:
+		return NewRedisCache(redisService)
+	default:
+		log.Printf("Unknown cache backend %q, using Redis", backend)
+		return NewRedisCache(redisService)
+	}
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// UserKey caches a user's profile
+func UserKey(userID string) string {
+	return fmt.Sprintf("user:%s", userID)
+}
+
+// MatchesKey caches one page of a user's matches. The page identifies the
+// filter, cursor and limit it was requested with.
+func MatchesKey(userID, page string) string {
+	return fmt.Sprintf("matches:%s:%s", userID, page)
+}
+
+// PotentialMatchesKey caches a discovery deck. The page identifies the
+// parameters it was requested with.
+func PotentialMatchesKey(userID, page string) string {
+	return fmt.Sprintf("potential_matches:%s:%s", userID, page)
+}
+
+// GIFSearchKey caches a page of GIF search results
+func GIFSearchKey(query, rating string, limit, offset int) string {
+	return fmt.Sprintf("gifs:search:%s:%d:%d:%s", rating, limit, offset, url.QueryEscape(query))
+}
+
+// GIFTrendingKey caches a page of trending GIFs
+func GIFTrendingKey(rating string, limit, offset int) string {
+	return fmt.Sprintf("gifs:trending:%s:%d:%d", rating, limit, offset)
+}
+
+// GIFKey caches a GIF looked up by its provider ID
+func GIFKey(id string) string {
+	return fmt.Sprintf("gifs:gif:%s", url.QueryEscape(id))
+}
+
+// MatchesTag tags every cached page of a user's matches
+func MatchesTag(userID string) string {
+	return fmt.Sprintf("user:%s:matches", userID)
+}
+
+// PotentialMatchesTag tags every cached discovery deck of a user
+func PotentialMatchesTag(userID string) string {
+	return fmt.Sprintf("user:%s:potential_matches", userID)
+}
+
+// InvalidateUser removes all cached data for a user
+func InvalidateUser(c Cache, userID string) error {
+	if err := c.Delete(UserKey(userID)); err != nil {
+		return fmt.Errorf("failed to delete cached user: %w", err)
+	}
+	return c.InvalidateTags(MatchesTag(userID), PotentialMatchesTag(userID))
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"time"
+)
+
+// LayeredCache keeps hot entries in a local memory cache (L1) in front of the
+// shared Redis cache (L2). Writes and invalidations on this instance reach
+// both layers; other instances' writes only reach its L1 once the local copy
+// expires, so local entries live at most localTTL.
+type LayeredCache struct {
+	local    *MemoryCache
+	remote   *RedisCache
+	localTTL time.Duration
+}
+
+// NewLayeredCache creates a cache reading through local to remote
+func NewLayeredCache(local *MemoryCache, remote *RedisCache, localTTL time.Duration) *LayeredCache {
+	return &LayeredCache{local: local, remote: remote, localTTL: localTTL}
+}
+
+// Get decodes the cached value into dest, or returns ErrMiss. Values found in
+// Redis are kept locally for next time.
+func (c *LayeredCache) Get(key string, dest any) error {
+	if err := c.local.Get(key, dest); err == nil {
+		return nil
+	}
+
+	if err := c.remote.Get(key, dest); err != nil {
+		return err
+	}
+	return c.local.Set(key, dest, c.localTTL)
+}
+
+// Set stores a value in both layers and registers it under the given tags
+func (c *LayeredCache) Set(key string, value any, ttl time.Duration, tags ...string) error {
+	if err := c.remote.Set(key, value, ttl, tags...); err != nil {
+		return err
+	}
+	return c.local.Set(key, value, min(ttl, c.localTTL), tags...)
+}
+
+// Delete removes the given keys from both layers
+func (c *LayeredCache) Delete(keys ...string) error {
+	if err := c.remote.Delete(keys...); err != nil {
+		return err
+	}
+	return c.local.Delete(keys...)
+}
+
+// InvalidateTags removes every entry registered under any of the tags from
+// both layers. Local copies read through from Redis carry no tags, so the
+// keys Redis had registered are removed locally by name.
+func (c *LayeredCache) InvalidateTags(tags ...string) error {
+	keys, err := c.remote.redis.InvalidateTags(tags...)
+	if err != nil {
+		return err
+	}
+	if err := c.local.Delete(keys...); err != nil {
+		return err
+	}
+	return c.local.InvalidateTags(tags...)
+}
+
+// Increment adds delta to a counter kept in Redis, so every instance counts
+// together
+func (c *LayeredCache) Increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	if err := c.local.Delete(key); err != nil {
+		return 0, err
+	}
+	return c.remote.Increment(key, delta, ttl)
+}
+
+// Expire sets a new TTL on a key in Redis and drops the local copy
+func (c *LayeredCache) Expire(key string, ttl time.Duration) error {
+	if err := c.local.Delete(key); err != nil {
+		return err
+	}
+	return c.remote.Expire(key, ttl)
+}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryCache is a size-bounded LRU Cache local to this instance. Values are
+// stored JSON-encoded, like in Redis, so callers never share mutable data.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List // Most recently used first
+	entries    map[string]*list.Element
+	tags       map[string]map[string]struct{} // Tag to the keys registered under it
+}
+
+// memoryEntry is a single cached value
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time // Zero if the entry never expires
+	tags      []string
+}
+
+// expired reports whether the entry has outlived its TTL
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// NewMemoryCache creates an LRU cache holding at most maxEntries entries
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+		tags:       make(map[string]map[string]struct{}),
+	}
+}
+
+// Get decodes the cached value into dest, or returns ErrMiss
+func (c *MemoryCache) Get(key string, dest any) error {
+	c.mu.Lock()
+	entry := c.lookup(key)
+	var value []byte
+	if entry != nil {
+		value = entry.value
+	}
+	c.mu.Unlock()
+
+	if entry == nil {
+		return ErrMiss
+	}
+	return json.Unmarshal(value, dest)
+}
+
+// Set stores a value for ttl and registers it under the given tags
+func (c *MemoryCache) Set(key string, value any, ttl time.Duration, tags ...string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(key, data, ttl, tags)
+	return nil
+}
+
+// Delete removes the given keys
+func (c *MemoryCache) Delete(keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if element, ok := c.entries[key]; ok {
+			c.remove(element)
+		}
+	}
+	return nil
+}
+
+// InvalidateTags removes every entry registered under any of the tags
+func (c *MemoryCache) InvalidateTags(tags ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, tag := range tags {
+		for key := range c.tags[tag] {
+			if element, ok := c.entries[key]; ok {
+				c.remove(element)
+			}
+		}
+		delete(c.tags, tag)
+	}
+	return nil
+}
+
+// Increment adds delta to a counter and returns the new value. A new counter
+// expires after ttl; a zero ttl keeps it until deleted.
+func (c *MemoryCache) Increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.lookup(key)
+	if entry == nil {
+		value := delta
+		c.store(key, []byte(strconv.FormatInt(value, 10)), ttl, nil)
+		return value, nil
+	}
+
+	current, err := strconv.ParseInt(string(entry.value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cached value is not a counter: %w", err)
+	}
+	entry.value = []byte(strconv.FormatInt(current+delta, 10))
+	return current + delta, nil
+}
+
+// Expire sets a new TTL on a key
+func (c *MemoryCache) Expire(key string, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry := c.lookup(key); entry != nil {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	return nil
+}
+
+// lookup returns the live entry for key and marks it recently used. Expired
+// entries are dropped. Callers must hold the lock.
+func (c *MemoryCache) lookup(key string) *memoryEntry {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if entry.expired(time.Now()) {
+		c.remove(element)
+		return nil
+	}
+	c.order.MoveToFront(element)
+	return entry
+}
+
+// store inserts or replaces an entry and evicts the least recently used
+// entries over the limit. Callers must hold the lock.
+func (c *MemoryCache) store(key string, value []byte, ttl time.Duration, tags []string) {
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+
+	entry := &memoryEntry{key: key, value: value, tags: tags}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for _, tag := range tags {
+		if c.tags[tag] == nil {
+			c.tags[tag] = make(map[string]struct{})
+		}
+		c.tags[tag][key] = struct{}{}
+	}
+
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// remove drops an entry and its tag registrations. Callers must hold the lock.
+func (c *MemoryCache) remove(element *list.Element) {
+	entry := c.order.Remove(element).(*memoryEntry)
+	delete(c.entries, entry.key)
+	for _, tag := range entry.tags {
+		delete(c.tags[tag], entry.key)
+		if len(c.tags[tag]) == 0 {
+			delete(c.tags, tag)
+		}
+	}
+}
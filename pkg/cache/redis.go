@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"time"
+
+	"matching-api/pkg/services"
+)
+
+// RedisCache is a Cache shared by every instance, backed by Redis
+type RedisCache struct {
+	redis *services.RedisService
+}
+
+// NewRedisCache creates a cache on top of the Redis service
+func NewRedisCache(redisService *services.RedisService) *RedisCache {
+	return &RedisCache{redis: redisService}
+}
+
+// Get decodes the cached value into dest, or returns ErrMiss
+func (c *RedisCache) Get(key string, dest any) error {
+	return c.redis.Get(key, dest)
+}
+
+// Set stores a value for ttl and registers it under the given tags
+func (c *RedisCache) Set(key string, value any, ttl time.Duration, tags ...string) error {
+	if len(tags) == 0 {
+		return c.redis.Set(key, value, ttl)
+	}
+	return c.redis.SetWithTags(key, value, ttl, tags...)
+}
+
+// Delete removes the given keys
+func (c *RedisCache) Delete(keys ...string) error {
+	for _, key := range keys {
+		if err := c.redis.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InvalidateTags removes every entry registered under any of the tags
+func (c *RedisCache) InvalidateTags(tags ...string) error {
+	_, err := c.redis.InvalidateTags(tags...)
+	return err
+}
+
+// Increment adds delta to a counter and returns the new value
+func (c *RedisCache) Increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	return c.redis.Increment(key, delta, ttl)
+}
+
+// Expire sets a new TTL on a key
+func (c *RedisCache) Expire(key string, ttl time.Duration) error {
+	return c.redis.Expire(key, ttl)
+}
@@ -0,0 +1,104 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// EarthRadiusKm is the mean radius of the Earth in kilometers
+const EarthRadiusKm = 6371.0
+
+// kmPerDegreeLat is the length of one degree of latitude in kilometers
+const kmPerDegreeLat = 111.32
+
+// BoundingBox is a latitude/longitude rectangle. When MinLng > MaxLng the box
+// crosses the antimeridian.
+type BoundingBox struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// Contains reports whether the point lies inside the box
+func (b BoundingBox) Contains(lat, lng float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLng <= b.MaxLng {
+		return lng >= b.MinLng && lng <= b.MaxLng
+	}
+	return lng >= b.MinLng || lng <= b.MaxLng
+}
+
+// DistanceKm returns the great-circle distance between two points using the
+// Haversine formula
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	lat1Rad := toRadians(lat1)
+	lat2Rad := toRadians(lat2)
+	deltaLat := toRadians(lat2 - lat1)
+	deltaLng := toRadians(lng2 - lng1)
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
+			math.Sin(deltaLng/2)*math.Sin(deltaLng/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return EarthRadiusKm * c
+}
+
+// Bounds returns the smallest box containing every point within radiusKm of
+// the center. Boxes reaching a pole span all longitudes.
+func Bounds(lat, lng, radiusKm float64) BoundingBox {
+	deltaLat := radiusKm / kmPerDegreeLat
+	box := BoundingBox{
+		MinLat: math.Max(lat-deltaLat, -90),
+		MaxLat: math.Min(lat+deltaLat, 90),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+	if box.MinLat == -90 || box.MaxLat == 90 {
+		return box
+	}
+
+	// Longitude degrees shrink with latitude; use the widest edge of the box
+	cosLat := math.Cos(toRadians(math.Max(math.Abs(box.MinLat), math.Abs(box.MaxLat))))
+	deltaLng := radiusKm / (kmPerDegreeLat * cosLat)
+	if deltaLng >= 180 {
+		return box
+	}
+
+	box.MinLng = normalizeLng(lng - deltaLng)
+	box.MaxLng = normalizeLng(lng + deltaLng)
+	return box
+}
+
+func toRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// normalizeLng wraps a longitude into [-180, 180]
+func normalizeLng(lng float64) float64 {
+	for lng > 180 {
+		lng -= 360
+	}
+	for lng < -180 {
+		lng += 360
+	}
+	return lng
+}
+
+// distanceBuckets are the upper bounds, in kilometers, of the distances shown
+// on other users' profiles
+var distanceBuckets = []float64{1, 2, 5, 10, 25, 50, 100}
+
+// DistanceBucket rounds a distance up to a coarse label such as "less than 5 km",
+// so exact positions cannot be triangulated from repeated lookups
+func DistanceBucket(km float64) string {
+	for _, bound := range distanceBuckets {
+		if km < bound {
+			return fmt.Sprintf("less than %d km", int(bound))
+		}
+	}
+	return fmt.Sprintf("more than %d km", int(distanceBuckets[len(distanceBuckets)-1]))
+}
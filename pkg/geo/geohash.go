@@ -0,0 +1,150 @@
+package geo
+
+import (
+	"math"
+	"strings"
+)
+
+// MaxPrecision is the geohash length stored for users (cells of about 5 m)
+const MaxPrecision = 9
+
+const base32 = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Encode returns the geohash of a point at the given precision
+func Encode(lat, lng float64, precision int) string {
+	if precision < 1 {
+		precision = 1
+	}
+	if precision > 12 {
+		precision = 12
+	}
+
+	minLat, maxLat := -90.0, 90.0
+	minLng, maxLng := -180.0, 180.0
+
+	var hash strings.Builder
+	hash.Grow(precision)
+
+	bit, ch := 0, 0
+	evenBit := true // Bits alternate between longitude and latitude
+	for hash.Len() < precision {
+		if evenBit {
+			mid := (minLng + maxLng) / 2
+			if lng >= mid {
+				ch = ch<<1 | 1
+				minLng = mid
+			} else {
+				ch <<= 1
+				maxLng = mid
+			}
+		} else {
+			mid := (minLat + maxLat) / 2
+			if lat >= mid {
+				ch = ch<<1 | 1
+				minLat = mid
+			} else {
+				ch <<= 1
+				maxLat = mid
+			}
+		}
+		evenBit = !evenBit
+
+		if bit++; bit == 5 {
+			hash.WriteByte(base32[ch])
+			bit, ch = 0, 0
+		}
+	}
+
+	return hash.String()
+}
+
+// DecodeBounds returns the cell covered by a geohash
+func DecodeBounds(hash string) BoundingBox {
+	box := BoundingBox{MinLat: -90, MaxLat: 90, MinLng: -180, MaxLng: 180}
+
+	evenBit := true
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(base32, hash[i])
+		if idx < 0 {
+			break
+		}
+		for n := 4; n >= 0; n-- {
+			bitSet := idx>>uint(n)&1 == 1
+			if evenBit {
+				mid := (box.MinLng + box.MaxLng) / 2
+				if bitSet {
+					box.MinLng = mid
+				} else {
+					box.MaxLng = mid
+				}
+			} else {
+				mid := (box.MinLat + box.MaxLat) / 2
+				if bitSet {
+					box.MinLat = mid
+				} else {
+					box.MaxLat = mid
+				}
+			}
+			evenBit = !evenBit
+		}
+	}
+
+	return box
+}
+
+// cellSizeKm returns the height and width of a geohash cell at the given
+// precision and latitude
+func cellSizeKm(lat float64, precision int) (height, width float64) {
+	bits := 5 * precision
+	lngBits := (bits + 1) / 2
+	latBits := bits / 2
+
+	height = 180 / math.Pow(2, float64(latBits)) * kmPerDegreeLat
+	width = 360 / math.Pow(2, float64(lngBits)) * kmPerDegreeLat * math.Cos(toRadians(lat))
+	return height, width
+}
+
+// PrecisionForRadius returns the longest geohash whose cells are at least as
+// large as the radius, so a cell and its eight neighbors cover the circle
+func PrecisionForRadius(lat, radiusKm float64) int {
+	precision := 1
+	for p := 2; p <= MaxPrecision; p++ {
+		height, width := cellSizeKm(lat, p)
+		if height < radiusKm || width < radiusKm {
+			break
+		}
+		precision = p
+	}
+	return precision
+}
+
+// CoveringCells returns the geohash prefixes that together cover every point
+// within radiusKm of the center: the center's cell and its neighbors
+func CoveringCells(lat, lng, radiusKm float64) []string {
+	precision := PrecisionForRadius(lat, radiusKm)
+	center := Encode(lat, lng, precision)
+	cell := DecodeBounds(center)
+
+	cellLat := (cell.MinLat + cell.MaxLat) / 2
+	cellLng := (cell.MinLng + cell.MaxLng) / 2
+	dLat := cell.MaxLat - cell.MinLat
+	dLng := cell.MaxLng - cell.MinLng
+
+	seen := map[string]bool{center: true}
+	cells := []string{center}
+	for _, i := range []float64{-1, 0, 1} {
+		for _, j := range []float64{-1, 0, 1} {
+			neighborLat := cellLat + i*dLat
+			if neighborLat < -90 || neighborLat > 90 {
+				continue
+			}
+			neighbor := Encode(neighborLat, normalizeLng(cellLng+j*dLng), precision)
+			if !seen[neighbor] {
+				seen[neighbor] = true
+				cells = append(cells, neighbor)
+			}
+		}
+	}
+
+	return cells
+}
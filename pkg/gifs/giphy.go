@@ -0,0 +1,163 @@
+package gifs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"matching-api/internal/models"
+)
+
+// GiphyProvider searches the Giphy API, or any service answering in its
+// format, such as a local stub in tests
+type GiphyProvider struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+// NewGiphyProvider creates a provider calling the API at baseURL
+func NewGiphyProvider(baseURL, apiKey string) *GiphyProvider {
+	return &GiphyProvider{
+		baseURL: baseURL,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// giphyGIF is a GIF object in Giphy responses. Dimensions are strings.
+type giphyGIF struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Rating string `json:"rating"`
+	Images struct {
+		Original   giphyRendition `json:"original"`
+		FixedWidth giphyRendition `json:"fixed_width"`
+	} `json:"images"`
+}
+
+type giphyRendition struct {
+	URL    string `json:"url"`
+	Width  string `json:"width"`
+	Height string `json:"height"`
+}
+
+type giphyPagination struct {
+	TotalCount int `json:"total_count"`
+	Count      int `json:"count"`
+	Offset     int `json:"offset"`
+}
+
+// Search returns GIFs matching the query text
+func (p *GiphyProvider) Search(ctx context.Context, query Query) (*models.GIFPage, error) {
+	params := p.pageParams(query)
+	params.Set("q", query.Text)
+	return p.list(ctx, "/v1/gifs/search", params)
+}
+
+// Trending returns currently popular GIFs
+func (p *GiphyProvider) Trending(ctx context.Context, query Query) (*models.GIFPage, error) {
+	return p.list(ctx, "/v1/gifs/trending", p.pageParams(query))
+}
+
+// Get returns a GIF by its Giphy ID
+func (p *GiphyProvider) Get(ctx context.Context, id string) (*models.GIF, error) {
+	params := url.Values{}
+	params.Set("api_key", p.apiKey)
+
+	var response struct {
+		Data giphyGIF `json:"data"`
+	}
+	if err := p.get(ctx, "/v1/gifs/"+url.PathEscape(id), params, &response); err != nil {
+		return nil, err
+	}
+	if response.Data.ID == "" {
+		return nil, ErrNotFound
+	}
+
+	gif := convertGiphyGIF(response.Data)
+	return &gif, nil
+}
+
+func (p *GiphyProvider) pageParams(query Query) url.Values {
+	params := url.Values{}
+	params.Set("api_key", p.apiKey)
+	params.Set("limit", strconv.Itoa(query.Limit))
+	params.Set("offset", strconv.Itoa(query.Offset))
+	params.Set("rating", query.Rating)
+	return params
+}
+
+func (p *GiphyProvider) list(ctx context.Context, path string, params url.Values) (*models.GIFPage, error) {
+	var response struct {
+		Data       []giphyGIF      `json:"data"`
+		Pagination giphyPagination `json:"pagination"`
+	}
+	if err := p.get(ctx, path, params, &response); err != nil {
+		return nil, err
+	}
+
+	page := &models.GIFPage{
+		GIFs:   make([]models.GIF, 0, len(response.Data)),
+		Offset: response.Pagination.Offset,
+	}
+	for _, gif := range response.Data {
+		page.GIFs = append(page.GIFs, convertGiphyGIF(gif))
+	}
+	if next := response.Pagination.Offset + response.Pagination.Count; response.Pagination.Count > 0 && next < response.Pagination.TotalCount {
+		page.NextOffset = next
+	}
+	return page, nil
+}
+
+// get calls the API and decodes the JSON response into dest
+func (p *GiphyProvider) get(ctx context.Context, path string, params url.Values, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create gif request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gif provider request failed: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("gif provider returned status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode gif provider response: %w", err)
+	}
+	return nil
+}
+
+func convertGiphyGIF(gif giphyGIF) models.GIF {
+	width, _ := strconv.Atoi(gif.Images.Original.Width)
+	height, _ := strconv.Atoi(gif.Images.Original.Height)
+
+	preview := gif.Images.FixedWidth.URL
+	if preview == "" {
+		preview = gif.Images.Original.URL
+	}
+
+	return models.GIF{
+		ID:         gif.ID,
+		Title:      gif.Title,
+		Rating:     gif.Rating,
+		URL:        gif.Images.Original.URL,
+		Width:      width,
+		Height:     height,
+		PreviewURL: preview,
+	}
+}
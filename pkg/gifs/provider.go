@@ -0,0 +1,62 @@
+package gifs
+
+import (
+	"context"
+	"errors"
+
+	"matching-api/internal/models"
+)
+
+// ErrNotFound is returned for GIF IDs the provider did not issue, or whose
+// rating is above the allowed maximum
+var ErrNotFound = errors.New("gif not found")
+
+// Content ratings, from most to least restrictive
+const (
+	RatingG    = "g"
+	RatingPG   = "pg"
+	RatingPG13 = "pg-13"
+	RatingR    = "r"
+)
+
+// ratingLevels orders the ratings so they can be compared
+var ratingLevels = map[string]int{
+	RatingG:    0,
+	RatingPG:   1,
+	RatingPG13: 2,
+	RatingR:    3,
+}
+
+// ValidRating reports whether rating is one of the known content ratings
+func ValidRating(rating string) bool {
+	_, ok := ratingLevels[rating]
+	return ok
+}
+
+// ratingAllowed reports whether content rated rating may be shown under the
+// maximum rating. Unknown ratings count as the least restrictive.
+func ratingAllowed(rating, maximum string) bool {
+	level, ok := ratingLevels[rating]
+	if !ok {
+		level = ratingLevels[RatingR]
+	}
+	return level <= ratingLevels[maximum]
+}
+
+// Query selects a page of results
+type Query struct {
+	Text   string // Search terms, empty for trending
+	Rating string // Highest content rating to include
+	Limit  int
+	Offset int
+}
+
+// Provider is a GIF search API such as Giphy or Tenor
+type Provider interface {
+	// Search returns GIFs matching the query text
+	Search(ctx context.Context, query Query) (*models.GIFPage, error)
+	// Trending returns currently popular GIFs
+	Trending(ctx context.Context, query Query) (*models.GIFPage, error)
+	// Get returns a GIF by the ID the provider issued, or ErrNotFound
+	Get(ctx context.Context, id string) (*models.GIF, error)
+}
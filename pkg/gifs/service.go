@@ -0,0 +1,149 @@
+package gifs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"matching-api/internal/models"
+	"matching-api/pkg/cache"
+)
+
+// DefaultBaseURL is the Giphy API, used unless GIF_API_BASE_URL is set
+const DefaultBaseURL = "https://api.giphy.com"
+
+// How long provider responses are cached. Trending changes quickly; a GIF
+// looked up by ID to validate a message does not change at all.
+const (
+	searchCacheTTL   = 10 * time.Minute
+	trendingCacheTTL = 5 * time.Minute
+	gifCacheTTL      = 24 * time.Hour
+)
+
+// Service proxies GIF searches to a provider, caches the results and keeps
+// them within the configured content rating
+type Service struct {
+	provider  Provider
+	cache     cache.Cache
+	maxRating string
+}
+
+// NewService creates a service on top of the given provider. Content above
+// maxRating is never returned.
+func NewService(provider Provider, cacheStore cache.Cache, maxRating string) *Service {
+	return &Service{provider: provider, cache: cacheStore, maxRating: maxRating}
+}
+
+// NewServiceFromEnv creates a service for the Giphy-compatible API at
+// GIF_API_BASE_URL using GIF_API_KEY, capped at GIF_MAX_RATING (default
+// pg-13). It returns nil if neither the key nor the URL is set.
+func NewServiceFromEnv(cacheStore cache.Cache) (*Service, error) {
+	apiKey := os.Getenv("GIF_API_KEY")
+	baseURL := strings.TrimRight(os.Getenv("GIF_API_BASE_URL"), "/")
+	if apiKey == "" && baseURL == "" {
+		log.Println("GIF_API_KEY not set, GIF search disabled")
+		return nil, nil
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	maxRating := strings.ToLower(os.Getenv("GIF_MAX_RATING"))
+	if maxRating == "" {
+		maxRating = RatingPG13
+	}
+	if !ValidRating(maxRating) {
+		return nil, fmt.Errorf("invalid GIF_MAX_RATING %q", maxRating)
+	}
+
+	return NewService(NewGiphyProvider(baseURL, apiKey), cacheStore, maxRating), nil
+}
+
+// Rating returns the rating to filter by for a requested one: the configured
+// maximum if none was requested, and never more than it
+func (s *Service) Rating(requested string) (string, error) {
+	requested = strings.ToLower(requested)
+	if requested == "" {
+		return s.maxRating, nil
+	}
+	if !ValidRating(requested) {
+		return "", fmt.Errorf("rating must be one of g, pg, pg-13 or r")
+	}
+	if !ratingAllowed(requested, s.maxRating) {
+		return s.maxRating, nil
+	}
+	return requested, nil
+}
+
+// Search returns a page of GIFs matching text
+func (s *Service) Search(ctx context.Context, text, rating string, limit, offset int) (*models.GIFPage, error) {
+	text = strings.ToLower(strings.Join(strings.Fields(text), " "))
+	query := Query{Text: text, Rating: rating, Limit: limit, Offset: offset}
+	return s.page(cache.GIFSearchKey(text, rating, limit, offset), searchCacheTTL, query, func() (*models.GIFPage, error) {
+		return s.provider.Search(ctx, query)
+	})
+}
+
+// Trending returns a page of currently popular GIFs
+func (s *Service) Trending(ctx context.Context, rating string, limit, offset int) (*models.GIFPage, error) {
+	query := Query{Rating: rating, Limit: limit, Offset: offset}
+	return s.page(cache.GIFTrendingKey(rating, limit, offset), trendingCacheTTL, query, func() (*models.GIFPage, error) {
+		return s.provider.Trending(ctx, query)
+	})
+}
+
+// Get returns a GIF by its provider ID, or ErrNotFound if the provider didn't
+// issue it or it is rated above the maximum
+func (s *Service) Get(ctx context.Context, id string) (*models.GIF, error) {
+	key := cache.GIFKey(id)
+	var gif models.GIF
+	if err := s.cache.Get(key, &gif); err != nil {
+		found, err := s.provider.Get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		gif = *found
+		if err := s.cache.Set(key, gif, gifCacheTTL); err != nil {
+			log.Printf("Failed to cache gif %s: %v", id, err)
+		}
+	}
+
+	if !ratingAllowed(gif.Rating, s.maxRating) {
+		return nil, ErrNotFound
+	}
+	return &gif, nil
+}
+
+// page returns a cached page of results, fetching and caching it on a miss.
+// Results rated above the query's rating are dropped in case the provider
+// doesn't filter.
+func (s *Service) page(key string, ttl time.Duration, query Query, fetch func() (*models.GIFPage, error)) (*models.GIFPage, error) {
+	var page models.GIFPage
+	if err := s.cache.Get(key, &page); err == nil {
+		return &page, nil
+	} else if !errors.Is(err, cache.ErrMiss) {
+		log.Printf("Failed to read cached gifs: %v", err)
+	}
+
+	fetched, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+
+	gifs := fetched.GIFs[:0]
+	for _, gif := range fetched.GIFs {
+		if ratingAllowed(gif.Rating, query.Rating) {
+			gifs = append(gifs, gif)
+		}
+	}
+	fetched.GIFs = gifs
+
+	if err := s.cache.Set(key, fetched, ttl); err != nil {
+		log.Printf("Failed to cache gifs: %v", err)
+	}
+	return fetched, nil
+}
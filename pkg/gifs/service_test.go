@@ -0,0 +1,253 @@
+package gifs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"matching-api/pkg/cache"
+)
+
+// stubGiphy answers like the Giphy API from a fixed catalogue. It ignores the
+// rating parameter, like a provider that doesn't filter, and records every
+// request so tests can check what reached it.
+type stubGiphy struct {
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+var stubCatalogue = []giphyGIF{
+	stubGIF("g1", RatingG),
+	stubGIF("pg1", RatingPG),
+	stubGIF("r1", RatingR),
+	stubGIF("pg13", RatingPG13),
+	stubGIF("g2", RatingG),
+}
+
+func stubGIF(id, rating string) giphyGIF {
+	gif := giphyGIF{ID: id, Title: "GIF " + id, Rating: rating}
+	gif.Images.Original = giphyRendition{URL: "https://media.example/" + id + ".gif", Width: "480", Height: "270"}
+	gif.Images.FixedWidth = giphyRendition{URL: "https://media.example/" + id + "_200w.gif", Width: "200", Height: "113"}
+	return gif
+}
+
+func (s *stubGiphy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r)
+	s.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/v1/gifs/search", "/v1/gifs/trending":
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		data := []giphyGIF{}
+		if offset < len(stubCatalogue) {
+			data = stubCatalogue[offset:min(offset+limit, len(stubCatalogue))]
+		}
+		writeStubJSON(w, map[string]any{
+			"data": data,
+			"pagination": giphyPagination{
+				TotalCount: len(stubCatalogue),
+				Count:      len(data),
+				Offset:     offset,
+			},
+		})
+	default:
+		id := strings.TrimPrefix(r.URL.Path, "/v1/gifs/")
+		for _, gif := range stubCatalogue {
+			if gif.ID == id {
+				writeStubJSON(w, map[string]any{"data": gif})
+				return
+			}
+		}
+		http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+	}
+}
+
+func writeStubJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// requestCount returns how many requests reached the stub for the path
+func (s *stubGiphy) requestCount(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count := 0
+	for _, r := range s.requests {
+		if r.URL.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+// lastQuery returns the query parameters of the latest request
+func (s *stubGiphy) lastQuery() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	query := map[string]string{}
+	for name, values := range s.requests[len(s.requests)-1].URL.Query() {
+		query[name] = values[0]
+	}
+	return query
+}
+
+// newStubService returns a service capped at maxRating on top of a stub
+// Giphy server
+func newStubService(t *testing.T, maxRating string) (*Service, *stubGiphy) {
+	stub := &stubGiphy{}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+	return NewService(NewGiphyProvider(server.URL, "test-key"), cache.NewMemoryCache(100), maxRating), stub
+}
+
+func TestServiceRating(t *testing.T) {
+	service, _ := newStubService(t, RatingPG13)
+
+	tests := []struct {
+		requested string
+		want      string
+		wantErr   bool
+	}{
+		{requested: "", want: RatingPG13},
+		{requested: "g", want: RatingG},
+		{requested: "PG", want: RatingPG},
+		{requested: "pg-13", want: RatingPG13},
+		{requested: "r", want: RatingPG13},
+		{requested: "nc-17", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := service.Rating(tt.requested)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Rating(%q): expected an error, got %q", tt.requested, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Rating(%q): unexpected error: %v", tt.requested, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Rating(%q) = %q, want %q", tt.requested, got, tt.want)
+		}
+	}
+}
+
+func TestServiceSearchDropsResultsAboveRating(t *testing.T) {
+	service, stub := newStubService(t, RatingPG13)
+
+	page, err := service.Search(context.Background(), "cats", RatingPG, 5, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for _, gif := range page.GIFs {
+		ids = append(ids, gif.ID)
+	}
+	if got, want := strings.Join(ids, ","), "g1,pg1,g2"; got != want {
+		t.Errorf("GIFs = %s, want %s", got, want)
+	}
+
+	query := stub.lastQuery()
+	for name, want := range map[string]string{"q": "cats", "rating": RatingPG, "limit": "5", "offset": "0", "api_key": "test-key"} {
+		if query[name] != want {
+			t.Errorf("provider got %s=%q, want %q", name, query[name], want)
+		}
+	}
+}
+
+func TestServicePagesNextOffset(t *testing.T) {
+	service, _ := newStubService(t, RatingR)
+
+	tests := []struct {
+		offset         int
+		wantOffset     int
+		wantNextOffset int
+	}{
+		{offset: 0, wantOffset: 0, wantNextOffset: 2},
+		{offset: 2, wantOffset: 2, wantNextOffset: 4},
+		{offset: 4, wantOffset: 4, wantNextOffset: 0}, // Last page
+		{offset: 6, wantOffset: 6, wantNextOffset: 0}, // Past the end
+	}
+
+	for _, tt := range tests {
+		page, err := service.Trending(context.Background(), RatingR, 2, tt.offset)
+		if err != nil {
+			t.Fatalf("offset %d: unexpected error: %v", tt.offset, err)
+		}
+		if page.Offset != tt.wantOffset || page.NextOffset != tt.wantNextOffset {
+			t.Errorf("offset %d: Offset = %d, NextOffset = %d, want %d and %d",
+				tt.offset, page.Offset, page.NextOffset, tt.wantOffset, tt.wantNextOffset)
+		}
+	}
+}
+
+func TestServiceCachesPages(t *testing.T) {
+	service, stub := newStubService(t, RatingPG13)
+	ctx := context.Background()
+
+	// Queries differing only in case and spacing share a cache entry
+	for _, text := range []string{"happy cat", "  Happy   CAT ", "happy cat"} {
+		if _, err := service.Search(ctx, text, RatingPG13, 2, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := stub.requestCount("/v1/gifs/search"); got != 1 {
+		t.Errorf("provider got %d search requests, want 1", got)
+	}
+
+	// Another page, rating or query is fetched separately
+	if _, err := service.Search(ctx, "happy cat", RatingPG13, 2, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Search(ctx, "happy cat", RatingG, 2, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.Search(ctx, "sad cat", RatingPG13, 2, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stub.requestCount("/v1/gifs/search"); got != 4 {
+		t.Errorf("provider got %d search requests, want 4", got)
+	}
+}
+
+func TestServiceGet(t *testing.T) {
+	service, stub := newStubService(t, RatingPG13)
+	ctx := context.Background()
+
+	gif, err := service.Get(ctx, "pg13")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gif.ID != "pg13" || gif.Rating != RatingPG13 || gif.Width != 480 || gif.Height != 270 ||
+		gif.URL != "https://media.example/pg13.gif" || gif.PreviewURL != "https://media.example/pg13_200w.gif" {
+		t.Errorf("unexpected GIF: %+v", gif)
+	}
+
+	// Served from the cache the second time
+	if _, err := service.Get(ctx, "pg13"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stub.requestCount("/v1/gifs/pg13"); got != 1 {
+		t.Errorf("provider got %d requests for the GIF, want 1", got)
+	}
+
+	if _, err := service.Get(ctx, "unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown ID: err = %v, want ErrNotFound", err)
+	}
+
+	// The provider issued it, but it is rated above the maximum
+	if _, err := service.Get(ctx, "r1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GIF rated r: err = %v, want ErrNotFound", err)
+	}
+}
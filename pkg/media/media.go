@@ -0,0 +1,117 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Register decoders for image.Decode
+	"image/jpeg"
+	_ "image/png"
+)
+
+// maxPixels bounds the images that are decoded, so a small file claiming huge
+// dimensions can't exhaust memory
+const maxPixels = 40_000_000
+
+// ErrUnsupportedImage is returned for data that is not a JPEG, PNG or GIF
+var ErrUnsupportedImage = errors.New("unsupported image format")
+
+// Info describes an uploaded image
+type Info struct {
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// Inspect reads an image's format and dimensions without decoding it
+func Inspect(data []byte) (*Info, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("image dimensions %dx%d are not supported", config.Width, config.Height)
+	}
+
+	return &Info{
+		ContentType: "image/" + format,
+		Width:       config.Width,
+		Height:      config.Height,
+	}, nil
+}
+
+// Thumbnail returns a JPEG of the image scaled to fit within maxSize pixels on
+// its longer side. Smaller images keep their size. Transparent areas become
+// white. Animated GIFs are represented by their first frame.
+func Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	if _, err := Inspect(data); err != nil {
+		return nil, err
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), maxSize)
+
+	// Flatten onto white first, JPEG has no transparency
+	flat := image.NewRGBA(bounds)
+	draw.Draw(flat, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, bounds, src, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, scale(flat, width, height), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// fit returns the size of a width by height image scaled down to fit within
+// maxSize on its longer side
+func fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+	if width >= height {
+		return maxSize, max(height*maxSize/width, 1)
+	}
+	return max(width*maxSize/height, 1), maxSize
+}
+
+// scale resizes src to width by height, averaging the source pixels that fall
+// into each destination pixel
+func scale(src *image.RGBA, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	if bounds.Dx() == width && bounds.Dy() == height {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					offset := src.PixOffset(sx, sy)
+					r += uint32(src.Pix[offset])
+					g += uint32(src.Pix[offset+1])
+					b += uint32(src.Pix[offset+2])
+					a += uint32(src.Pix[offset+3])
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
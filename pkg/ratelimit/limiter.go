@@ -0,0 +1,195 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"matching-api/pkg/services"
+)
+
+// memorySweepInterval is how often the in-memory store drops stale counters
+const memorySweepInterval = time.Minute
+
+// CounterStore keeps the per-window counters of a sliding-window limit.
+// Implementations must check and count in one atomic step.
+type CounterStore interface {
+	// Take counts n requests for key unless the sliding-window count would
+	// exceed limit. It returns whether they were counted and the counts of the
+	// current and previous fixed windows.
+	Take(key string, n, limit int, window time.Duration, now time.Time) (taken bool, current, previous int64, err error)
+}
+
+// Result is the outcome of a rate limit check
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // Until the current window ends
+	RetryAfter time.Duration // Until the request would be allowed, zero if it was
+}
+
+// SetHeaders sets the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset
+// and RateLimit-Policy headers for a limit over window, and Retry-After if
+// the request was rejected. Durations are rounded up to whole seconds.
+func (r *Result) SetHeaders(header http.Header, window time.Duration) {
+	header.Set("RateLimit-Limit", strconv.Itoa(r.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(r.Remaining))
+	header.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(r.Reset.Seconds()))))
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", r.Limit, int(window.Seconds())))
+	if !r.Allowed && r.RetryAfter > 0 {
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(r.RetryAfter.Seconds()))))
+	}
+}
+
+// Limiter enforces sliding-window rate limits. The count over the window is
+// the current fixed window plus the previous one, weighted by how much of it
+// the sliding window still overlaps.
+type Limiter struct {
+	store CounterStore
+	now   func() time.Time
+}
+
+// NewLimiter returns a limiter backed by Redis when available, falling back
+// to an in-memory store otherwise
+func NewLimiter(redisService *services.RedisService) *Limiter {
+	if redisService != nil {
+		return NewLimiterWithStore(&redisCounterStore{redis: redisService})
+	}
+	return NewLimiterWithStore(newMemoryCounterStore())
+}
+
+// NewLimiterWithStore returns a limiter on top of the given counter store
+func NewLimiterWithStore(store CounterStore) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+// Allow counts one request for key against limit requests per window
+func (l *Limiter) Allow(key string, limit int, window time.Duration) (*Result, error) {
+	return l.AllowN(key, 1, limit, window)
+}
+
+// AllowN counts n requests for key against limit requests per window. Denied
+// requests are not counted.
+func (l *Limiter) AllowN(key string, n, limit int, window time.Duration) (*Result, error) {
+	now := l.now()
+	taken, current, previous, err := l.store.Take(key, n, limit, window, now)
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := time.Duration(now.UnixMilli()%window.Milliseconds()) * time.Millisecond
+	count := weightedCount(current, previous, window, elapsed)
+
+	result := &Result{
+		Allowed:   taken,
+		Limit:     limit,
+		Remaining: max(limit-int(math.Ceil(count)), 0),
+		Reset:     window - elapsed,
+	}
+	if !taken {
+		result.RetryAfter = retryAfter(current, previous, n, limit, window, elapsed)
+	}
+	return result, nil
+}
+
+// weightedCount is the number of requests in the sliding window
+func weightedCount(current, previous int64, window, elapsed time.Duration) float64 {
+	overlap := float64(window-elapsed) / float64(window)
+	return float64(previous)*overlap + float64(current)
+}
+
+// retryAfter returns how long until n more requests fit within the limit
+func retryAfter(current, previous int64, n, limit int, window, elapsed time.Duration) time.Duration {
+	spare := float64(limit - n)
+
+	// The previous window has to slide out far enough to make room
+	if headroom := spare - float64(current); headroom >= 0 {
+		if previous == 0 {
+			return 0
+		}
+		wait := time.Duration(float64(window)*(1-headroom/float64(previous))) - elapsed
+		return max(wait, 0)
+	}
+
+	// The current window alone is over the limit, so wait for the next window
+	// and for enough of this one to slide out
+	wait := window - elapsed
+	if spare < float64(current) {
+		wait += time.Duration(float64(window) * (1 - max(spare, 0)/float64(current)))
+	}
+	return wait
+}
+
+// redisCounterStore keeps counters in Redis so limits hold across instances
+type redisCounterStore struct {
+	redis *services.RedisService
+}
+
+func (s *redisCounterStore) Take(key string, n, limit int, window time.Duration, now time.Time) (bool, int64, int64, error) {
+	return s.redis.TakeRateLimit(key, n, limit, window, now)
+}
+
+// memoryCounterStore is used when Redis is not configured
+type memoryCounterStore struct {
+	mu        sync.Mutex
+	counters  map[string]*windowCounter
+	lastSweep time.Time
+}
+
+type windowCounter struct {
+	window   time.Duration
+	index    int64 // Fixed window the current count belongs to
+	current  int64
+	previous int64
+}
+
+func newMemoryCounterStore() *memoryCounterStore {
+	return &memoryCounterStore{
+		counters:  make(map[string]*windowCounter),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *memoryCounterStore) Take(key string, n, limit int, window time.Duration, now time.Time) (bool, int64, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= memorySweepInterval {
+		s.sweepLocked(now)
+	}
+
+	index := now.UnixMilli() / window.Milliseconds()
+	counter, exists := s.counters[key]
+	if !exists || counter.window != window {
+		counter = &windowCounter{window: window, index: index}
+		s.counters[key] = counter
+	}
+	switch {
+	case counter.index == index-1:
+		counter.previous, counter.current = counter.current, 0
+	case counter.index < index-1:
+		counter.previous, counter.current = 0, 0
+	}
+	counter.index = index
+
+	elapsed := time.Duration(now.UnixMilli()%window.Milliseconds()) * time.Millisecond
+	if weightedCount(counter.current, counter.previous, window, elapsed)+float64(n) > float64(limit) {
+		return false, counter.current, counter.previous, nil
+	}
+	counter.current += int64(n)
+	return true, counter.current, counter.previous, nil
+}
+
+// sweepLocked drops counters that no longer affect any window
+func (s *memoryCounterStore) sweepLocked(now time.Time) {
+	for key, counter := range s.counters {
+		if now.UnixMilli()/counter.window.Milliseconds() > counter.index+1 {
+			delete(s.counters, key)
+		}
+	}
+	s.lastSweep = now
+}
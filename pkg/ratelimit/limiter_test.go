@@ -0,0 +1,182 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"matching-api/pkg/services"
+)
+
+// counterStores returns the stores every conformance test runs against
+func counterStores() map[string]func(t *testing.T) CounterStore {
+	return map[string]func(t *testing.T) CounterStore{
+		"memory": func(t *testing.T) CounterStore {
+			return newMemoryCounterStore()
+		},
+		"redis": func(t *testing.T) CounterStore {
+			server := miniredis.RunT(t)
+			t.Setenv("REDIS_HOST", server.Host())
+			t.Setenv("REDIS_PORT", server.Port())
+			redisService, err := services.NewRedisService()
+			if err != nil {
+				t.Fatalf("failed to connect to miniredis: %v", err)
+			}
+			t.Cleanup(func() { _ = redisService.Close() })
+			return &redisCounterStore{redis: redisService}
+		},
+	}
+}
+
+// windowStart is aligned to the start of a fixed window of any whole number
+// of seconds that divides a day
+var windowStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// step is one request at an offset from windowStart and the expected outcome
+type step struct {
+	at            time.Duration
+	n             int
+	allowed       bool
+	remaining     string
+	reset         string
+	retryAfter    string // Empty if the header must not be set
+	retryAfterDur time.Duration
+}
+
+func TestLimiterConformance(t *testing.T) {
+	const window = time.Minute
+
+	tests := []struct {
+		name  string
+		limit int
+		steps []step
+	}{
+		{
+			name:  "fills the window then rejects until the count slides out",
+			limit: 3,
+			steps: []step{
+				{at: 0, n: 1, allowed: true, remaining: "2", reset: "60"},
+				{at: 0, n: 1, allowed: true, remaining: "1", reset: "60"},
+				{at: 0, n: 1, allowed: true, remaining: "0", reset: "60"},
+				// The next window must start and a third of it pass before 3
+				// weighted requests drop to 2
+				{at: 0, n: 1, allowed: false, remaining: "0", reset: "60", retryAfter: "80", retryAfterDur: 80 * time.Second},
+				{at: 59*time.Second + 999*time.Millisecond, n: 1, allowed: false, remaining: "0", reset: "1", retryAfter: "21", retryAfterDur: 20*time.Second + time.Millisecond},
+				{at: 79 * time.Second, n: 1, allowed: false, remaining: "0", reset: "41", retryAfter: "1", retryAfterDur: time.Second},
+				{at: 80 * time.Second, n: 1, allowed: true, remaining: "0", reset: "40"},
+			},
+		},
+		{
+			name:  "previous window is weighted by its overlap at the boundary",
+			limit: 2,
+			steps: []step{
+				{at: 59*time.Second + 999*time.Millisecond, n: 2, allowed: true, remaining: "0", reset: "1"},
+				// One millisecond later the previous window still counts fully
+				{at: 60 * time.Second, n: 1, allowed: false, remaining: "0", reset: "60", retryAfter: "30", retryAfterDur: 30 * time.Second},
+				{at: 90 * time.Second, n: 1, allowed: true, remaining: "0", reset: "30"},
+				{at: 105 * time.Second, n: 1, allowed: false, remaining: "0", reset: "15", retryAfter: "15", retryAfterDur: 15 * time.Second},
+			},
+		},
+		{
+			name:  "counters older than the previous window are ignored",
+			limit: 2,
+			steps: []step{
+				{at: 0, n: 2, allowed: true, remaining: "0", reset: "60"},
+				{at: 120 * time.Second, n: 1, allowed: true, remaining: "1", reset: "60"},
+			},
+		},
+		{
+			name:  "rejected requests are not counted",
+			limit: 2,
+			steps: []step{
+				{at: 0, n: 1, allowed: true, remaining: "1", reset: "60"},
+				{at: 10 * time.Second, n: 2, allowed: false, remaining: "1", reset: "50", retryAfter: "110", retryAfterDur: 110 * time.Second},
+				{at: 20 * time.Second, n: 1, allowed: true, remaining: "0", reset: "40"},
+			},
+		},
+	}
+
+	for storeName, newStore := range counterStores() {
+		t.Run(storeName, func(t *testing.T) {
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					limiter := NewLimiterWithStore(newStore(t))
+					for i, s := range tt.steps {
+						limiter.now = func() time.Time { return windowStart.Add(s.at) }
+
+						result, err := limiter.AllowN(t.Name(), s.n, tt.limit, window)
+						if err != nil {
+							t.Fatalf("step %d: unexpected error: %v", i, err)
+						}
+						if result.Allowed != s.allowed {
+							t.Fatalf("step %d: allowed = %v, want %v", i, result.Allowed, s.allowed)
+						}
+						if result.RetryAfter != s.retryAfterDur {
+							t.Errorf("step %d: RetryAfter = %v, want %v", i, result.RetryAfter, s.retryAfterDur)
+						}
+
+						header := http.Header{}
+						result.SetHeaders(header, window)
+						want := map[string]string{
+							"RateLimit-Limit":     strconv.Itoa(tt.limit),
+							"RateLimit-Remaining": s.remaining,
+							"RateLimit-Reset":     s.reset,
+							"RateLimit-Policy":    fmt.Sprintf("%d;w=60", tt.limit),
+							"Retry-After":         s.retryAfter,
+						}
+						for name, value := range want {
+							if got := header.Get(name); got != value {
+								t.Errorf("step %d: %s = %q, want %q", i, name, got, value)
+							}
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func TestLimiterConcurrentIncrements(t *testing.T) {
+	const (
+		limit      = 100
+		goroutines = 50
+		perWorker  = 4
+	)
+
+	for storeName, newStore := range counterStores() {
+		t.Run(storeName, func(t *testing.T) {
+			limiter := NewLimiterWithStore(newStore(t))
+			limiter.now = func() time.Time { return windowStart.Add(30 * time.Second) }
+
+			var allowed atomic.Int64
+			var wg sync.WaitGroup
+			for range goroutines {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range perWorker {
+						result, err := limiter.Allow("concurrent", limit, time.Minute)
+						if err != nil {
+							t.Errorf("unexpected error: %v", err)
+							return
+						}
+						if result.Allowed {
+							allowed.Add(1)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := allowed.Load(); got != limit {
+				t.Errorf("allowed %d of %d concurrent requests, want exactly %d", got, goroutines*perWorker, limit)
+			}
+		})
+	}
+}
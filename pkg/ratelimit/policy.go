@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultConfigPath is read unless RATE_LIMIT_CONFIG points elsewhere
+const DefaultConfigPath = "configs/app.yaml"
+
+// What a policy counts requests by
+const (
+	KeyByIP     = "ip"     // Client IP
+	KeyByUser   = "user"   // Authenticated user, or IP before login
+	KeyByDevice = "device" // X-Device-ID header, or user, or IP
+)
+
+// Policy is a named limit attached to one or more routes
+type Policy struct {
+	Limit  int            `yaml:"limit"`
+	Window time.Duration  `yaml:"window"`
+	Key    string         `yaml:"key"`
+	Tiers  map[string]int `yaml:"tiers"` // Limit per subscription tier, overriding Limit
+}
+
+// LimitFor returns the limit that applies to a subscription tier
+func (p Policy) LimitFor(tier string) int {
+	if limit, ok := p.Tiers[tier]; ok {
+		return limit
+	}
+	return p.Limit
+}
+
+// Config is the rate_limiting block of the configuration file
+type Config struct {
+	Enabled      bool              `yaml:"enabled"`
+	Policies     map[string]Policy `yaml:"policies"`
+	ExemptTokens []string          `yaml:"exempt_tokens"` // Internal service tokens that are never limited
+}
+
+// DefaultConfig returns the built-in policies, used for any policy the
+// configuration file leaves out
+func DefaultConfig() *Config {
+	return &Config{
+		Enabled: true,
+		Policies: map[string]Policy{
+			"api":          {Limit: 100, Window: time.Minute, Key: KeyByIP},
+			"auth":         {Limit: 10, Window: time.Minute, Key: KeyByIP},
+			"register":     {Limit: 5, Window: time.Hour, Key: KeyByIP},
+			"user":         {Limit: 200, Window: time.Minute, Key: KeyByUser},
+			"swipe":        {Limit: 60, Window: time.Minute, Key: KeyByUser},
+			"message_send": {Limit: 30, Window: time.Minute, Key: KeyByUser},
+			"image_upload": {Limit: 20, Window: time.Hour, Key: KeyByUser},
+			"gif_search":   {Limit: 60, Window: time.Minute, Key: KeyByUser},
+		},
+	}
+}
+
+// LoadConfig reads the rate_limiting block from the file at RATE_LIMIT_CONFIG,
+// or configs/app.yaml, on top of the defaults. Tokens in
+// RATE_LIMIT_EXEMPT_TOKENS (comma separated) are exempt as well.
+func LoadConfig() (*Config, error) {
+	config := DefaultConfig()
+
+	path := os.Getenv("RATE_LIMIT_CONFIG")
+	if path == "" {
+		path = DefaultConfigPath
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read rate limit config: %w", err)
+	}
+	if err == nil {
+		var file struct {
+			RateLimiting struct {
+				Enabled      *bool             `yaml:"enabled"`
+				Policies     map[string]Policy `yaml:"policies"`
+				ExemptTokens []string          `yaml:"exempt_tokens"`
+			} `yaml:"rate_limiting"`
+		}
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse rate limit config %s: %w", path, err)
+		}
+
+		if file.RateLimiting.Enabled != nil {
+			config.Enabled = *file.RateLimiting.Enabled
+		}
+		for name, policy := range file.RateLimiting.Policies {
+			config.Policies[name] = policy
+		}
+		config.ExemptTokens = append(config.ExemptTokens, file.RateLimiting.ExemptTokens...)
+	}
+
+	for _, token := range strings.Split(os.Getenv("RATE_LIMIT_EXEMPT_TOKENS"), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			config.ExemptTokens = append(config.ExemptTokens, token)
+		}
+	}
+
+	for name, policy := range config.Policies {
+		if err := policy.validate(); err != nil {
+			return nil, fmt.Errorf("rate limit policy %q: %w", name, err)
+		}
+	}
+
+	return config, nil
+}
+
+// validate checks that the policy can be enforced
+func (p Policy) validate() error {
+	if p.Limit <= 0 {
+		return fmt.Errorf("limit must be positive")
+	}
+	if p.Window < time.Second {
+		return fmt.Errorf("window must be at least one second")
+	}
+	switch p.Key {
+	case KeyByIP, KeyByUser, KeyByDevice:
+	default:
+		return fmt.Errorf("unknown key %q", p.Key)
+	}
+	for tier, limit := range p.Tiers {
+		if limit <= 0 {
+			return fmt.Errorf("limit for tier %q must be positive", tier)
+		}
+	}
+	return nil
+}
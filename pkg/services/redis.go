@@ -3,9 +3,11 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -13,8 +15,9 @@ import (
 
 // RedisService handles all Redis operations
 type RedisService struct {
-	client *redis.Client
-	ctx    context.Context
+	client     *redis.Client
+	ctx        context.Context
+	keyVersion string // Cache namespace, see cacheKey
 }
 
 // RedisConfig holds Redis configuration
@@ -51,8 +54,9 @@ func NewRedisService() (*RedisService, error) {
 	}
 
 	return &RedisService{
-		client: client,
-		ctx:    ctx,
+		client:     client,
+		ctx:        ctx,
+		keyVersion: getEnvOrDefault("CACHE_KEY_VERSION", defaultCacheKeyVersion),
 	}, nil
 }
 
@@ -95,81 +99,242 @@ func (r *RedisService) ExtendSession(sessionID string, expiration time.Duration)
 	return r.client.Expire(r.ctx, key, expiration).Err()
 }
 
-// Caching Methods
+// User Session Index Methods
+
+// AddUserSession indexes a session under its owning user so all of a user's
+// devices can be listed and revoked. The index maps session ID to refresh token.
+func (r *RedisService) AddUserSession(userID, sessionID, refreshToken string, expiration time.Duration) error {
+	key := fmt.Sprintf("user_sessions:%s", userID)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(r.ctx, key, sessionID, refreshToken)
+	pipe.Expire(r.ctx, key, expiration)
+
+	if _, err := pipe.Exec(r.ctx); err != nil {
+		return fmt.Errorf("failed to index user session: %w", err)
+	}
+	return nil
+}
 
-// CacheUser stores user data with TTL
-func (r *RedisService) CacheUser(userID string, userData any, ttl time.Duration) error {
-	jsonData, err := json.Marshal(userData)
+// GetUserSessions returns the session ID to refresh token index for a user
+func (r *RedisService) GetUserSessions(userID string) (map[string]string, error) {
+	key := fmt.Sprintf("user_sessions:%s", userID)
+	sessions, err := r.client.HGetAll(r.ctx, key).Result()
 	if err != nil {
-		return fmt.Errorf("failed to marshal user data: %w", err)
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
 	}
+	return sessions, nil
+}
 
-	key := fmt.Sprintf("user:%s", userID)
-	return r.client.Set(r.ctx, key, jsonData, ttl).Err()
+// RemoveUserSession removes a session from a user's session index
+func (r *RedisService) RemoveUserSession(userID, sessionID string) error {
+	key := fmt.Sprintf("user_sessions:%s", userID)
+	return r.client.HDel(r.ctx, key, sessionID).Err()
 }
 
-// GetCachedUser retrieves cached user data
-func (r *RedisService) GetCachedUser(userID string, dest any) error {
-	key := fmt.Sprintf("user:%s", userID)
-	val, err := r.client.Get(r.ctx, key).Result()
+// DeleteUserSessions deletes every session of a user along with the index and
+// returns how many sessions were removed
+func (r *RedisService) DeleteUserSessions(userID string) (int, error) {
+	key := fmt.Sprintf("user_sessions:%s", userID)
+	sessions, err := r.client.HGetAll(r.ctx, key).Result()
 	if err != nil {
-		if err == redis.Nil {
-			return fmt.Errorf("user not found in cache")
-		}
-		return fmt.Errorf("failed to get cached user: %w", err)
+		return 0, fmt.Errorf("failed to get user sessions: %w", err)
 	}
 
-	return json.Unmarshal([]byte(val), dest)
+	keys := make([]string, 0, len(sessions)+1)
+	for _, refreshToken := range sessions {
+		keys = append(keys, fmt.Sprintf("session:%s", refreshToken))
+	}
+	keys = append(keys, key)
+
+	if err := r.client.Del(r.ctx, keys...).Err(); err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	return len(sessions), nil
 }
 
-// CacheMatches stores user matches with TTL
-func (r *RedisService) CacheMatches(userID string, matches any, ttl time.Duration) error {
-	jsonData, err := json.Marshal(matches)
+// Token Revocation Methods
+
+// RevokeToken adds a token or session ID to the denylist until the TTL elapses
+func (r *RedisService) RevokeToken(id string, ttl time.Duration) error {
+	key := fmt.Sprintf("revoked_token:%s", id)
+	return r.client.Set(r.ctx, key, "1", ttl).Err()
+}
+
+// IsTokenRevoked checks whether a token or session ID is on the denylist
+func (r *RedisService) IsTokenRevoked(id string) (bool, error) {
+	key := fmt.Sprintf("revoked_token:%s", id)
+	result, err := r.client.Exists(r.ctx, key).Result()
 	if err != nil {
-		return fmt.Errorf("failed to marshal matches data: %w", err)
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
 	}
+	return result > 0, nil
+}
 
-	key := fmt.Sprintf("matches:%s", userID)
-	return r.client.Set(r.ctx, key, jsonData, ttl).Err()
+// SetTokensRevokedBefore stores the time before which a user's tokens are invalid
+func (r *RedisService) SetTokensRevokedBefore(userID string, before time.Time, ttl time.Duration) error {
+	key := fmt.Sprintf("tokens_revoked_before:%s", userID)
+	return r.client.Set(r.ctx, key, before.UnixMilli(), ttl).Err()
 }
 
-// GetCachedMatches retrieves cached matches
-func (r *RedisService) GetCachedMatches(userID string, dest any) error {
-	key := fmt.Sprintf("matches:%s", userID)
-	val, err := r.client.Get(r.ctx, key).Result()
+// GetTokensRevokedBefore returns the revocation watermark for a user, or the
+// zero time if none is set
+func (r *RedisService) GetTokensRevokedBefore(userID string) (time.Time, error) {
+	key := fmt.Sprintf("tokens_revoked_before:%s", userID)
+	val, err := r.client.Get(r.ctx, key).Int64()
 	if err != nil {
 		if err == redis.Nil {
-			return fmt.Errorf("matches not found in cache")
+			return time.Time{}, nil
 		}
-		return fmt.Errorf("failed to get cached matches: %w", err)
+		return time.Time{}, fmt.Errorf("failed to get token revocation watermark: %w", err)
 	}
+	return time.UnixMilli(val), nil
+}
 
-	return json.Unmarshal([]byte(val), dest)
+// Login Attempt Methods
+
+// LoginAttemptPolicy configures the progressive delays and lockout applied by
+// TakeLoginAttempt
+type LoginAttemptPolicy struct {
+	FreeAttempts     int           // Failures allowed before delays apply
+	BaseDelay        time.Duration // First delay, doubled per further failure
+	MaxDelay         time.Duration
+	LockoutThreshold int // Failures that start a lockout
+	LockoutDuration  time.Duration
+	Window           time.Duration // How long failures are remembered
 }
 
-// CachePotentialMatches stores potential matches with TTL
-func (r *RedisService) CachePotentialMatches(userID string, matches any, ttl time.Duration) error {
-	jsonData, err := json.Marshal(matches)
+// Outcomes of TakeLoginAttempt
+const (
+	LoginAttemptAllowed     = 0 // The attempt was counted and may proceed
+	LoginAttemptDelayed     = 1 // An earlier failure's delay has not passed
+	LoginAttemptLocked      = 2 // Logins are locked out
+	LoginAttemptLockStarted = 3 // The attempt was counted, may proceed and started a lockout
+)
+
+// loginAttemptScript checks the lockout (KEYS[2]) and the delay owed for the
+// failures counted in KEYS[1] and, if the attempt may proceed, counts it as a
+// failure in the same step, so parallel attempts can't all pass the check
+// before any of them is counted. ARGV[1] is the current time and ARGV[3],
+// ARGV[4], ARGV[6] and ARGV[7] are durations, all in milliseconds; ARGV[2] is
+// the number of free attempts and ARGV[5] the lockout threshold. Reaching the
+// threshold clears the count so it starts over once the lockout ends. It
+// returns the outcome, the time to wait in milliseconds and the failure count.
+var loginAttemptScript = redis.NewScript(`
+local locked = redis.call('PTTL', KEYS[2])
+if locked > 0 then
+	return {2, locked, 0}
+end
+local now = tonumber(ARGV[1])
+local free = tonumber(ARGV[2])
+local count = tonumber(redis.call('HGET', KEYS[1], 'count') or '0')
+if count >= free then
+	local delay = math.min(tonumber(ARGV[3]) * 2 ^ (count - free), tonumber(ARGV[4]))
+	local last = tonumber(redis.call('HGET', KEYS[1], 'last_attempt') or '0')
+	local wait = last + delay - now
+	if wait > 0 then
+		return {1, wait, count}
+	end
+end
+count = redis.call('HINCRBY', KEYS[1], 'count', 1)
+if count >= tonumber(ARGV[5]) then
+	redis.call('DEL', KEYS[1])
+	redis.call('SET', KEYS[2], '1', 'PX', ARGV[6])
+	return {3, 0, count}
+end
+redis.call('HSET', KEYS[1], 'last_attempt', now)
+redis.call('PEXPIRE', KEYS[1], ARGV[7])
+return {0, 0, count}
+`)
+
+// TakeLoginAttempt atomically checks whether a login attempt for an
+// identifier may proceed and, if so, counts it as a failure until
+// ResetLoginFailures is called. It returns the outcome, how long to wait if
+// the attempt may not proceed, and the number of failures counted.
+func (r *RedisService) TakeLoginAttempt(identifier string, policy LoginAttemptPolicy, now time.Time) (int64, time.Duration, int64, error) {
+	// The hash tag keeps both keys on the same cluster slot
+	keys := []string{
+		fmt.Sprintf("login_failures:{%s}", identifier),
+		fmt.Sprintf("login_locked:{%s}", identifier),
+	}
+
+	result, err := loginAttemptScript.Run(r.ctx, r.client, keys,
+		now.UnixMilli(), policy.FreeAttempts, policy.BaseDelay.Milliseconds(), policy.MaxDelay.Milliseconds(),
+		policy.LockoutThreshold, policy.LockoutDuration.Milliseconds(), policy.Window.Milliseconds(),
+	).Int64Slice()
 	if err != nil {
-		return fmt.Errorf("failed to marshal potential matches data: %w", err)
+		return 0, 0, 0, fmt.Errorf("failed to take login attempt: %w", err)
 	}
+	return result[0], time.Duration(result[1]) * time.Millisecond, result[2], nil
+}
 
-	key := fmt.Sprintf("potential_matches:%s", userID)
-	return r.client.Set(r.ctx, key, jsonData, ttl).Err()
+// ResetLoginFailures clears the failed login counter and any lockout for an
+// identifier
+func (r *RedisService) ResetLoginFailures(identifier string) error {
+	return r.client.Del(r.ctx,
+		fmt.Sprintf("login_failures:{%s}", identifier),
+		fmt.Sprintf("login_locked:{%s}", identifier),
+	).Err()
 }
 
-// GetCachedPotentialMatches retrieves cached potential matches
-func (r *RedisService) GetCachedPotentialMatches(userID string, dest any) error {
-	key := fmt.Sprintf("potential_matches:%s", userID)
-	val, err := r.client.Get(r.ctx, key).Result()
-	if err != nil {
-		if err == redis.Nil {
-			return fmt.Errorf("potential matches not found in cache")
-		}
-		return fmt.Errorf("failed to get cached potential matches: %w", err)
-	}
+// Caching Methods
+//
+// Cache entries live under a versioned namespace, cache:{version}:{key}, where
+// the version comes from CACHE_KEY_VERSION. Bumping it on deploy orphans every
+// cached entry at once; PurgeStaleCacheKeys removes the orphans. Entries can be
+// registered under tags so everything derived from a user's matches, for
+// example, is invalidated in one atomic step.
+
+// defaultCacheKeyVersion is the cache namespace unless CACHE_KEY_VERSION is set
+const defaultCacheKeyVersion = "v1"
+
+// legacyCachePatterns match cache keys written before keys were versioned
+var legacyCachePatterns = []string{
+	"user:*",
+	"matches:*",
+	"potential_matches:*",
+	"matches_page_*",
+	"potential_matches_*",
+}
 
-	return json.Unmarshal([]byte(val), dest)
+// setWithTagsScript stores an entry and adds it to each tag set. A tag set
+// lives as long as its longest-lived entry.
+// KEYS[1] is the entry, KEYS[2..n] are tag sets; ARGV[1] is the value and
+// ARGV[2] the TTL in milliseconds.
+var setWithTagsScript = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+local ttl = tonumber(ARGV[2])
+for i = 2, #KEYS do
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if redis.call('PTTL', KEYS[i]) < ttl then
+		redis.call('PEXPIRE', KEYS[i], ttl)
+	end
+end
+return 1
+`)
+
+// incrementScript adds ARGV[1] to a counter and gives a counter without an
+// expiry the TTL in ARGV[2] milliseconds, if any
+var incrementScript = redis.NewScript(`
+local value = redis.call('INCRBY', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if ttl > 0 and redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return value
+`)
+
+// ErrCacheMiss is returned by Get when the key is not cached
+var ErrCacheMiss = errors.New("key not found in cache")
+
+// cacheKey places a key in the current cache namespace
+func (r *RedisService) cacheKey(key string) string {
+	return fmt.Sprintf("cache:%s:%s", r.keyVersion, key)
+}
+
+// tagKey returns the set holding the keys registered under a tag
+func (r *RedisService) tagKey(tag string) string {
+	return r.cacheKey("tag:" + tag)
 }
 
 // Generic Cache Methods
@@ -181,15 +346,36 @@ func (r *RedisService) Set(key string, value any, ttl time.Duration) error {
 		return fmt.Errorf("failed to marshal data: %w", err)
 	}
 
-	return r.client.Set(r.ctx, key, jsonData, ttl).Err()
+	return r.client.Set(r.ctx, r.cacheKey(key), jsonData, ttl).Err()
+}
+
+// SetWithTags stores any data with TTL and registers it under the given tags,
+// all in one atomic step
+func (r *RedisService) SetWithTags(key string, value any, ttl time.Duration, tags ...string) error {
+	if ttl <= 0 {
+		return fmt.Errorf("tagged cache entries need a TTL")
+	}
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	keys := make([]string, 0, len(tags)+1)
+	keys = append(keys, r.cacheKey(key))
+	for _, tag := range tags {
+		keys = append(keys, r.tagKey(tag))
+	}
+
+	return setWithTagsScript.Run(r.ctx, r.client, keys, jsonData, ttl.Milliseconds()).Err()
 }
 
 // Get retrieves any cached data
 func (r *RedisService) Get(key string, dest any) error {
-	val, err := r.client.Get(r.ctx, key).Result()
+	val, err := r.client.Get(r.ctx, r.cacheKey(key)).Result()
 	if err != nil {
 		if err == redis.Nil {
-			return fmt.Errorf("key not found in cache")
+			return ErrCacheMiss
 		}
 		return fmt.Errorf("failed to get cached data: %w", err)
 	}
@@ -199,67 +385,262 @@ func (r *RedisService) Get(key string, dest any) error {
 
 // Delete removes a key from cache
 func (r *RedisService) Delete(key string) error {
-	return r.client.Del(r.ctx, key).Err()
+	return r.client.Del(r.ctx, r.cacheKey(key)).Err()
+}
+
+// Increment adds delta to a cached counter and returns the new value. A new
+// counter expires after ttl; a zero ttl keeps it until deleted.
+func (r *RedisService) Increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	value, err := incrementScript.Run(r.ctx, r.client, []string{r.cacheKey(key)}, delta, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment cached counter: %w", err)
+	}
+	return value, nil
+}
+
+// Expire sets a new TTL on a cached key
+func (r *RedisService) Expire(key string, ttl time.Duration) error {
+	return r.client.Expire(r.ctx, r.cacheKey(key), ttl).Err()
+}
+
+// InvalidateTags deletes every cache entry registered under any of the tags
+// and returns the keys that were registered. Each tag set is read and removed
+// in one transaction, so an entry is either invalidated or registered anew;
+// the entries are then deleted. Entries are only deleted from Go, since a
+// script may only touch the keys it is passed.
+func (r *RedisService) InvalidateTags(tags ...string) ([]string, error) {
+	var members []string
+	for _, tag := range tags {
+		key := r.tagKey(tag)
+		var smembers *redis.StringSliceCmd
+		if _, err := r.client.TxPipelined(r.ctx, func(pipe redis.Pipeliner) error {
+			smembers = pipe.SMembers(r.ctx, key)
+			pipe.Del(r.ctx, key)
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to invalidate cache tag: %w", err)
+		}
+		members = append(members, smembers.Val()...)
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	if _, err := r.client.Pipelined(r.ctx, func(pipe redis.Pipeliner) error {
+		for _, member := range members {
+			pipe.Del(r.ctx, member)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to delete tagged cache entries: %w", err)
+	}
+
+	prefix := r.cacheKey("")
+	for i, member := range members {
+		members[i] = strings.TrimPrefix(member, prefix)
+	}
+	return members, nil
+}
+
+// DeleteByPattern removes every key matching a glob pattern and returns how
+// many were deleted. Keys are found with SCAN so Redis is never blocked. The
+// pattern matches raw keys, outside the cache namespace.
+func (r *RedisService) DeleteByPattern(pattern string) (int, error) {
+	return r.deleteScanned(pattern, nil)
+}
+
+// PurgeStaleCacheKeys deletes cache entries from other key versions and from
+// before keys were versioned. It is safe to run while serving traffic.
+func (r *RedisService) PurgeStaleCacheKeys() (int, error) {
+	current := r.cacheKey("")
+	deleted, err := r.deleteScanned("cache:*", func(key string) bool {
+		return strings.HasPrefix(key, current)
+	})
+	if err != nil {
+		return deleted, err
+	}
+
+	for _, pattern := range legacyCachePatterns {
+		n, err := r.DeleteByPattern(pattern)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// deleteScanned deletes the keys matching a pattern in batches, leaving out
+// those skip returns true for
+func (r *RedisService) deleteScanned(pattern string, skip func(key string) bool) (int, error) {
+	deleted := 0
+	iter := r.client.Scan(r.ctx, 0, pattern, 100).Iterator()
+
+	var batch []string
+	for iter.Next(r.ctx) {
+		if skip != nil && skip(iter.Val()) {
+			continue
+		}
+		batch = append(batch, iter.Val())
+		if len(batch) == 100 {
+			n, err := r.client.Del(r.ctx, batch...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete keys matching %s: %w", pattern, err)
+			}
+			deleted += int(n)
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, fmt.Errorf("failed to scan keys matching %s: %w", pattern, err)
+	}
+
+	if len(batch) > 0 {
+		n, err := r.client.Del(r.ctx, batch...).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete keys matching %s: %w", pattern, err)
+		}
+		deleted += int(n)
+	}
+
+	return deleted, nil
 }
 
 // Exists checks if a key exists in cache
 func (r *RedisService) Exists(key string) (bool, error) {
-	result, err := r.client.Exists(r.ctx, key).Result()
+	result, err := r.client.Exists(r.ctx, r.cacheKey(key)).Result()
 	return result > 0, err
 }
 
-// Invalidate User Cache removes all cached data for a user
-func (r *RedisService) InvalidateUserCache(userID string) error {
-	keys := []string{
-		fmt.Sprintf("user:%s", userID),
-		fmt.Sprintf("matches:%s", userID),
-		fmt.Sprintf("potential_matches:%s", userID),
+// Deck Priority Methods
+
+// maxDeckPriority caps how many profiles can be pinned to the top of a deck
+const maxDeckPriority = 50
+
+// PrioritizeInDeck pins a profile to the top of a user's discovery deck
+func (r *RedisService) PrioritizeInDeck(userID, targetID string, ttl time.Duration) error {
+	key := fmt.Sprintf("deck_priority:%s", userID)
+
+	pipe := r.client.TxPipeline()
+	pipe.LRem(r.ctx, key, 0, targetID)
+	pipe.LPush(r.ctx, key, targetID)
+	pipe.LTrim(r.ctx, key, 0, maxDeckPriority-1)
+	pipe.Expire(r.ctx, key, ttl)
+
+	if _, err := pipe.Exec(r.ctx); err != nil {
+		return fmt.Errorf("failed to prioritize profile in deck: %w", err)
 	}
+	return nil
+}
 
-	for _, key := range keys {
-		if err := r.client.Del(r.ctx, key).Err(); err != nil {
-			return fmt.Errorf("failed to delete key %s: %w", key, err)
-		}
+// GetDeckPriority returns the profiles pinned to the top of a user's deck, most recent first
+func (r *RedisService) GetDeckPriority(userID string) ([]string, error) {
+	key := fmt.Sprintf("deck_priority:%s", userID)
+	ids, err := r.client.LRange(r.ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deck priority: %w", err)
 	}
+	return ids, nil
+}
 
+// RemoveFromDeckPriority unpins a profile, e.g. once it has been swiped again
+func (r *RedisService) RemoveFromDeckPriority(userID, targetID string) error {
+	key := fmt.Sprintf("deck_priority:%s", userID)
+	if err := r.client.LRem(r.ctx, key, 0, targetID).Err(); err != nil {
+		return fmt.Errorf("failed to remove profile from deck priority: %w", err)
+	}
 	return nil
 }
 
-// Rate Limiting Methods
+// Swipe Quota Methods
 
-// IncrementRateLimit increments rate limit counter
-func (r *RedisService) IncrementRateLimit(identifier string, window time.Duration) (int64, error) {
-	key := fmt.Sprintf("rate_limit:%s", identifier)
+// IncrementSwipeCount increments a daily swipe counter (e.g. likes or super_likes)
+// and returns the new value. The key expires after ttl so counters reset daily.
+func (r *RedisService) IncrementSwipeCount(userID, day, field string, ttl time.Duration) (int64, error) {
+	key := fmt.Sprintf("swipe_quota:%s:%s", userID, day)
 
-	pipe := r.client.Pipeline()
-	incr := pipe.Incr(r.ctx, key)
-	pipe.Expire(r.ctx, key, window)
+	pipe := r.client.TxPipeline()
+	incr := pipe.HIncrBy(r.ctx, key, field, 1)
+	pipe.Expire(r.ctx, key, ttl)
 
-	_, err := pipe.Exec(r.ctx)
-	if err != nil {
-		return 0, fmt.Errorf("failed to increment rate limit: %w", err)
+	if _, err := pipe.Exec(r.ctx); err != nil {
+		return 0, fmt.Errorf("failed to increment swipe count: %w", err)
 	}
 
 	return incr.Val(), nil
 }
 
-// GetRateLimit gets current rate limit count
-func (r *RedisService) GetRateLimit(identifier string) (int64, error) {
-	key := fmt.Sprintf("rate_limit:%s", identifier)
-	val, err := r.client.Get(r.ctx, key).Result()
+// DecrementSwipeCount gives back a swipe counted by IncrementSwipeCount
+func (r *RedisService) DecrementSwipeCount(userID, day, field string) error {
+	key := fmt.Sprintf("swipe_quota:%s:%s", userID, day)
+	if err := r.client.HIncrBy(r.ctx, key, field, -1).Err(); err != nil {
+		return fmt.Errorf("failed to decrement swipe count: %w", err)
+	}
+	return nil
+}
+
+// GetSwipeCounts returns all daily swipe counters for a user
+func (r *RedisService) GetSwipeCounts(userID, day string) (map[string]int64, error) {
+	key := fmt.Sprintf("swipe_quota:%s:%s", userID, day)
+	values, err := r.client.HGetAll(r.ctx, key).Result()
 	if err != nil {
-		if err == redis.Nil {
-			return 0, nil
+		return nil, fmt.Errorf("failed to get swipe counts: %w", err)
+	}
+
+	counts := make(map[string]int64, len(values))
+	for field, value := range values {
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse swipe count: %w", err)
 		}
-		return 0, fmt.Errorf("failed to get rate limit: %w", err)
+		counts[field] = count
 	}
 
-	count, err := strconv.ParseInt(val, 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("failed to parse rate limit value: %w", err)
+	return counts, nil
+}
+
+// Rate Limiting Methods
+
+// slidingWindowScript adds ARGV[4] to the current window counter (KEYS[1]) if
+// the count over the sliding window stays within the limit. The previous
+// window (KEYS[2]) is weighted by how much of it still overlaps the window.
+// ARGV[1] is the limit, ARGV[2] the window and ARGV[3] the time elapsed in the
+// current window, both in milliseconds. It returns whether the request was
+// allowed and the current and previous window counts.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+local cost = tonumber(ARGV[4])
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local previous = tonumber(redis.call('GET', KEYS[2]) or '0')
+if previous * (window - elapsed) / window + current + cost > limit then
+	return {0, current, previous}
+end
+current = redis.call('INCRBY', KEYS[1], cost)
+redis.call('PEXPIRE', KEYS[1], window * 2)
+return {1, current, previous}
+`)
+
+// TakeRateLimit atomically counts n requests against a sliding-window limit,
+// unless that would exceed it. It returns whether they were counted and the
+// counts of the current and previous fixed windows.
+func (r *RedisService) TakeRateLimit(identifier string, n, limit int, window time.Duration, now time.Time) (bool, int64, int64, error) {
+	windowMs := window.Milliseconds()
+	index := now.UnixMilli() / windowMs
+	elapsed := now.UnixMilli() % windowMs
+
+	// The hash tag keeps both windows on the same cluster slot
+	keys := []string{
+		fmt.Sprintf("rate_limit:{%s}:%d", identifier, index),
+		fmt.Sprintf("rate_limit:{%s}:%d", identifier, index-1),
 	}
 
-	return count, nil
+	result, err := slidingWindowScript.Run(r.ctx, r.client, keys, limit, windowMs, elapsed, n).Int64Slice()
+	if err != nil {
+		return false, 0, 0, fmt.Errorf("failed to take rate limit: %w", err)
+	}
+	return result[0] == 1, result[1], result[2], nil
 }
 
 // Health Check
@@ -160,30 +160,40 @@ func (s *S3Service) DeleteImage(ctx context.Context, key string) error {
 	return nil
 }
 
-// DeleteImages deletes multiple images from S3
+// maxDeleteObjects is the S3 limit on keys per DeleteObjects request
+const maxDeleteObjects = 1000
+
+// DeleteImages deletes multiple images from S3, batching requests as needed
 func (s *S3Service) DeleteImages(ctx context.Context, keys []string) error {
-	if len(keys) == 0 {
-		return nil
-	}
+	for start := 0; start < len(keys); start += maxDeleteObjects {
+		end := start + maxDeleteObjects
+		if end > len(keys) {
+			end = len(keys)
+		}
 
-	// Convert keys to ObjectIdentifier slice
-	objects := make([]types.ObjectIdentifier, len(keys))
-	for i, key := range keys {
-		objects[i] = types.ObjectIdentifier{
-			Key: aws.String(key),
+		// Convert keys to ObjectIdentifier slice
+		batch := keys[start:end]
+		objects := make([]types.ObjectIdentifier, len(batch))
+		for i, key := range batch {
+			objects[i] = types.ObjectIdentifier{
+				Key: aws.String(key),
+			}
 		}
-	}
 
-	input := &s3.DeleteObjectsInput{
-		Bucket: aws.String(s.bucket),
-		Delete: &types.Delete{
-			Objects: objects,
-		},
-	}
+		input := &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucket),
+			Delete: &types.Delete{
+				Objects: objects,
+			},
+		}
 
-	_, err := s.client.DeleteObjects(ctx, input)
-	if err != nil {
-		return fmt.Errorf("failed to delete images: %w", err)
+		output, err := s.client.DeleteObjects(ctx, input)
+		if err != nil {
+			return fmt.Errorf("failed to delete images: %w", err)
+		}
+		if len(output.Errors) > 0 {
+			return fmt.Errorf("failed to delete %d images: %s", len(output.Errors), aws.ToString(output.Errors[0].Message))
+		}
 	}
 
 	return nil
@@ -228,6 +238,45 @@ func (s *S3Service) GeneratePresignedURL(ctx context.Context, userID string, con
 	}, nil
 }
 
+// UploadObject uploads an arbitrary private object under the given key
+func (s *S3Service) UploadObject(ctx context.Context, key string, body io.Reader, contentType string) (*UploadResult, error) {
+	input := &s3.PutObjectInput{
+		Bucket:               aws.String(s.bucket),
+		Key:                  aws.String(key),
+		Body:                 body,
+		ContentType:          aws.String(contentType),
+		ServerSideEncryption: types.ServerSideEncryptionAes256,
+	}
+
+	result, err := s.uploader.Upload(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return &UploadResult{
+		Key:         key,
+		ETag:        strings.Trim(aws.ToString(result.ETag), "\""),
+		ContentType: contentType,
+	}, nil
+}
+
+// GeneratePresignedDownloadURL generates a time-limited URL for downloading a private object
+func (s *S3Service) GeneratePresignedDownloadURL(ctx context.Context, key string, duration time.Duration) (string, error) {
+	presignClient := s3.NewPresignClient(s.client)
+
+	presignedReq, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = duration
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned download URL: %w", err)
+	}
+
+	return presignedReq.URL, nil
+}
+
 // GetImageMetadata retrieves metadata for an image
 func (s *S3Service) GetImageMetadata(ctx context.Context, key string) (*ImageMetadata, error) {
 	input := &s3.HeadObjectInput{
@@ -294,6 +343,18 @@ func (s *S3Service) ListUserImages(ctx context.Context, userID string) ([]ImageM
 	return images, nil
 }
 
+// ChatAttachmentKey returns where a chat attachment is stored. Attachments
+// are private objects under their chat's prefix, fetched through presigned
+// download URLs.
+func ChatAttachmentKey(chatID, attachmentID, contentType string) string {
+	return fmt.Sprintf("chats/%s/%s%s", chatID, attachmentID, getFileExtension(contentType))
+}
+
+// ChatAttachmentThumbnailKey returns where a chat attachment's JPEG thumbnail is stored
+func ChatAttachmentThumbnailKey(chatID, attachmentID string) string {
+	return fmt.Sprintf("chats/%s/%s_thumb.jpg", chatID, attachmentID)
+}
+
 // Helper functions
 
 func (s *S3Service) getPublicURL(key string) string {
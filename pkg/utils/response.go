@@ -3,7 +3,10 @@ package utils
 import (
 	"encoding/json"
 	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"matching-api/internal/models"
 )
@@ -74,6 +77,17 @@ func WriteNotFound(w http.ResponseWriter, message string) {
 	WriteErrorResponse(w, message, http.StatusNotFound)
 }
 
+// WriteTooManyRequests writes a rate limit error response with a Retry-After header
+func WriteTooManyRequests(w http.ResponseWriter, message string, retryAfter time.Duration) {
+	if message == "" {
+		message = "Too many requests"
+	}
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	WriteErrorResponse(w, message, http.StatusTooManyRequests)
+}
+
 // WriteCreated writes a created response
 func WriteCreated(w http.ResponseWriter, message string, data any) {
 	response := models.APIResponse{